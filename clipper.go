@@ -0,0 +1,42 @@
+package microui
+
+/*============================================================================
+** list clipper
+**============================================================================*/
+
+// begins a list of count items of the same height, of which only the ones in
+// the visible part of the current container need to be added. the layout is
+// moved to the first visible item and set to one item of itemHeight per row
+// (0 for the default height). add the items from clipper.Start up to
+// clipper.End, then call EndListClipper
+func (ctx *Context) BeginListClipper(count, itemHeight int) ListClipper {
+	cnt := ctx.GetCurrentContainer()
+	layout := ctx.GetLayout()
+	h := itemHeight
+	if h == 0 {
+		h = ctx.Style.Size.Y + ctx.Style.Padding*2
+	}
+	c := ListClipper{count: count, step: h + ctx.Style.Spacing, top: layout.NextRow}
+
+	// find the items that overlap the container's body
+	y := layout.Body.Y + layout.NextRow
+	c.Start = mu_clamp((cnt.Body.Y-y)/c.step, 0, count)
+	c.End = mu_clamp((cnt.Body.Y+cnt.Body.H-y+c.step-1)/c.step, c.Start, count)
+
+	// skip the items above
+	layout.NextRow += c.Start * c.step
+	ctx.LayoutRow(1, []int{-1}, h)
+	return c
+}
+
+// moves the layout past the items below the visible ones, so that the
+// container's content size still covers the whole list
+func (ctx *Context) EndListClipper(c ListClipper) {
+	layout := ctx.GetLayout()
+	if c.count > 0 {
+		layout.NextRow = mu_max(layout.NextRow, c.top+c.count*c.step)
+		layout.Max.Y = mu_max(layout.Max.Y, layout.Body.Y+layout.NextRow-ctx.Style.Spacing)
+	}
+	layout.Position = NewVec2(layout.Indent, layout.NextRow)
+	layout.ItemIndex = layout.Items
+}
@@ -0,0 +1,212 @@
+package microui
+
+import (
+	"math"
+	"unsafe"
+)
+
+/*============================================================================
+** color edit and picker
+**============================================================================*/
+
+// converts rgb (0 to 1) to hue, saturation and value (0 to 1)
+func rgbToHsv(r, g, b float32) (h, s, v float32) {
+	max := mu_max_real(r, mu_max_real(g, b))
+	min := mu_min_real(r, mu_min_real(g, b))
+	v = max
+	d := max - min
+	if max > 0 {
+		s = d / max
+	}
+	if d == 0 {
+		return 0, s, v
+	}
+	switch max {
+	case r:
+		h = (g - b) / d
+		if h < 0 {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h / 6, s, v
+}
+
+// converts hue, saturation and value (0 to 1) to rgb (0 to 1)
+func hsvToRgb(h, s, v float32) (r, g, b float32) {
+	h = (h - float32(math.Floor(float64(h)))) * 6
+	i := int(h)
+	f := h - float32(i)
+	p := v * (1 - s)
+	q := v * (1 - s*f)
+	t := v * (1 - s*(1-f))
+	switch i {
+	case 0:
+		return v, t, p
+	case 1:
+		return q, v, p
+	case 2:
+		return p, v, t
+	case 3:
+		return p, q, v
+	case 4:
+		return t, p, v
+	default:
+		return v, p, q
+	}
+}
+
+func hsvColor(h, s, v float32, a uint8) Color {
+	r, g, b := hsvToRgb(h, s, v)
+	return Color{
+		uint8(r*255 + 0.5),
+		uint8(g*255 + 0.5),
+		uint8(b*255 + 0.5),
+		a,
+	}
+}
+
+// number of cells the saturation/value square is drawn with along each side,
+// and of strips in the hue bar. each is a rect command, so they are kept few
+const (
+	colorPickerCells  = 8
+	colorPickerStrips = 12
+)
+
+// draws a saturation/value square and a hue bar next to it in the next layout
+// rect, clicking or dragging them sets the rgb channels of c. returns
+// MU_RES_CHANGE when c changed
+func (ctx *Context) ColorPicker(c *Color, opt int) int {
+	var res int = 0
+	ctx.PushID(unsafe.Slice((*byte)(unsafe.Pointer(&c)), unsafe.Sizeof(c)))
+	svID := ctx.GetID([]byte("!sv"))
+	hueID := ctx.GetID([]byte("!hue"))
+	ctx.PopID()
+	r := ctx.LayoutNext()
+	last := *c
+
+	huew := ctx.Style.ScrollbarSize + ctx.Style.Padding
+	sv := NewRect(r.X, r.Y, r.W-huew-ctx.Style.Spacing, r.H)
+	hue := NewRect(sv.X+sv.W+ctx.Style.Spacing, r.Y, huew, r.H)
+
+	// the hue is kept while the picker is used so that it doesn't get lost
+	// when the saturation or value is 0, recompute it if c was changed
+	// elsewhere
+	if ctx.colorPickerID != svID || hsvColor(ctx.colorPickerHSV[0], ctx.colorPickerHSV[1], ctx.colorPickerHSV[2], c.A) != *c {
+		h, s, v := rgbToHsv(float32(c.R)/255, float32(c.G)/255, float32(c.B)/255)
+		ctx.colorPickerID = svID
+		ctx.colorPickerHSV = [3]float32{h, s, v}
+	}
+	hsv := &ctx.colorPickerHSV
+
+	// handle input
+	ctx.UpdateControl(svID, sv, opt|MU_OPT_NONAV)
+	if ctx.Focus == svID && ctx.MouseDown == MU_MOUSE_LEFT && sv.W > 0 && sv.H > 0 {
+		hsv[1] = mu_clamp_real(float32(ctx.MousePos.X-sv.X)/float32(sv.W), 0, 1)
+		hsv[2] = 1 - mu_clamp_real(float32(ctx.MousePos.Y-sv.Y)/float32(sv.H), 0, 1)
+	}
+	ctx.UpdateControl(hueID, hue, opt|MU_OPT_NONAV)
+	if ctx.Focus == hueID && ctx.MouseDown == MU_MOUSE_LEFT && hue.H > 0 {
+		// stop just short of 1 so the hue doesn't wrap around to 0
+		hsv[0] = mu_clamp_real(float32(ctx.MousePos.Y-hue.Y)/float32(hue.H), 0, 0.9999)
+	}
+	if ctx.Focus == svID || ctx.Focus == hueID {
+		*c = hsvColor(hsv[0], hsv[1], hsv[2], c.A)
+	}
+	if *c != last {
+		res |= MU_RES_CHANGE
+	}
+	undoTrackValue(ctx, svID, c, last)
+	undoTrackValue(ctx, hueID, c, last)
+
+	// draw saturation/value square
+	cols := mu_max(mu_min(sv.W, colorPickerCells), 1)
+	rows := mu_max(mu_min(sv.H, colorPickerCells), 1)
+	for j := 0; j < rows; j++ {
+		y0, y1 := sv.Y+j*sv.H/rows, sv.Y+(j+1)*sv.H/rows
+		v := 1 - (float32(j)+0.5)/float32(rows)
+		for i := 0; i < cols; i++ {
+			x0, x1 := sv.X+i*sv.W/cols, sv.X+(i+1)*sv.W/cols
+			s := (float32(i) + 0.5) / float32(cols)
+			ctx.DrawRect(NewRect(x0, y0, x1-x0, y1-y0), hsvColor(hsv[0], s, v, 255))
+		}
+	}
+	// draw hue bar
+	strips := mu_max(mu_min(hue.H, colorPickerStrips), 1)
+	for j := 0; j < strips; j++ {
+		y0, y1 := hue.Y+j*hue.H/strips, hue.Y+(j+1)*hue.H/strips
+		h := (float32(j) + 0.5) / float32(strips)
+		ctx.DrawRect(NewRect(hue.X, y0, hue.W, y1-y0), hsvColor(h, 1, 1, 255))
+	}
+
+	// draw markers, dark on bright colors and bright on dark ones
+	marker := ctx.Style.Colors[MU_COLOR_TEXT]
+	if hsv[2] > 0.5 && hsv[1] < 0.5 {
+		marker = ctx.Style.Colors[MU_COLOR_BORDER]
+	}
+	mx := sv.X + int(hsv[1]*float32(sv.W))
+	my := sv.Y + int((1-hsv[2])*float32(sv.H))
+	ctx.DrawBox(NewRect(mx-3, my-3, 7, 7), marker)
+	hy := hue.Y + int(hsv[0]*float32(hue.H))
+	ctx.DrawBox(NewRect(hue.X-1, hy-2, hue.W+2, 5), ctx.Style.Colors[MU_COLOR_TEXT])
+
+	return res
+}
+
+// shows number fields for the r, g, b and a channels of c followed by a
+// swatch of the color, clicking the swatch opens a ColorPicker in a popup.
+// returns MU_RES_CHANGE when c changed
+func (ctx *Context) ColorEdit(c *Color, opt int) int {
+	var res int = 0
+	ctx.PushID(unsafe.Slice((*byte)(unsafe.Pointer(&c)), unsafe.Sizeof(c)))
+	r := ctx.LayoutNext()
+	last := *c
+
+	// do channel fields
+	channels := [4]*uint8{&c.R, &c.G, &c.B, &c.A}
+	formats := [4]string{"R %d", "G %d", "B %d", "A %d"}
+	swatchw := r.H * 2
+	x := r.X
+	for i, ch := range channels {
+		id := ctx.GetID([]byte(formats[i][:1]))
+		// split the width left of the swatch evenly between the fields
+		x1 := r.X + (i+1)*(r.W-swatchw)/4
+		fr := NewRect(x, r.Y, x1-x-ctx.Style.Spacing, r.H)
+		x = x1
+		v := int(*ch)
+		res |= numberRect(ctx, id, &v, 1, formats[i], fr, opt)
+		*ch = uint8(mu_clamp(v, 0, 255))
+		undoTrackValue(ctx, id, c, last)
+	}
+
+	// do swatch
+	id := ctx.GetID([]byte("!swatch"))
+	name := "!colorpicker"
+	sr := NewRect(x, r.Y, r.X+r.W-x, r.H)
+	ctx.UpdateControl(id, sr, opt)
+	if (ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id) || ctx.KeyActivated(id) {
+		ctx.OpenPopupAt(name, NewRect(r.X, r.Y+r.H, 1, 1))
+	}
+	ctx.DrawFrame(ctx, sr, MU_COLOR_BASE)
+	ctx.DrawRect(sr, *c)
+	ctx.DrawFocusRing(id, sr)
+
+	// do picker popup
+	if ctx.BeginPopup(name) != 0 {
+		size := ctx.Style.Size.X * 2
+		ctx.LayoutRow(1, []int{size + ctx.Style.ScrollbarSize + ctx.Style.Padding + ctx.Style.Spacing}, size)
+		res |= ctx.ColorPicker(c, 0)
+		ctx.EndPopup()
+	}
+	ctx.PopID()
+
+	ctx.LastID = id
+	ctx.LastRect = r
+	if *c != last {
+		res |= MU_RES_CHANGE
+	}
+	return res
+}
@@ -0,0 +1,45 @@
+package microui
+
+import "testing"
+
+// clicking the swatch opens the picker popup, LastRect has to stay on the
+// color edit
+func TestColorEditLastRect(t *testing.T) {
+	ctx := newTestContext()
+	c := Color{255, 0, 0, 255}
+	var r, last Rect
+	ui := func() {
+		ctx.LayoutRow(1, []int{-1}, 0)
+		r = ctx.LayoutNext()
+		ctx.LayoutSetNext(r, false)
+		ctx.ColorEdit(&c, 0)
+		last = ctx.LastRect
+	}
+	testFrame(ctx, ui)
+	testClick(ctx, ui, Vec2{r.X + r.W - 5, r.Y + r.H/2})
+	if ctx.colorPickerID == 0 {
+		t.Fatal("picker didn't open")
+	}
+	if last != r {
+		t.Errorf("LastRect = %v with the picker open, want %v", last, r)
+	}
+}
+
+// every cell of the picker is a rect command, a large picker must not flood
+// the command list
+func TestColorPickerCommandCount(t *testing.T) {
+	ctx := newTestContext()
+	c := Color{200, 80, 40, 255}
+	ctx.Begin()
+	if ctx.BeginWindow("Window", NewRect(0, 0, 600, 600)) {
+		ctx.LayoutRow(1, []int{500}, 500)
+		ctx.ColorPicker(&c, 0)
+		ctx.EndWindow()
+	}
+	ctx.End()
+	n := 0
+	ctx.Render(func(cmd *Command) { n++ })
+	if n > 120 {
+		t.Errorf("a 500x500 picker took %d commands", n)
+	}
+}
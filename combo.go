@@ -0,0 +1,132 @@
+package microui
+
+import "strconv"
+
+/*============================================================================
+** combo box
+**============================================================================*/
+
+// a dropdown that lets the user pick one of items. label identifies the combo
+// and is shown when *selected is not a valid index. returns MU_RES_CHANGE
+// when the selection changed
+func (ctx *Context) ComboEx(label string, selected *int, items []string, opt int) int {
+	var res int = 0
+	id := ctx.GetID([]byte(label))
+	name := label + "!combo"
+	r := ctx.LayoutNext()
+	ctx.UpdateControl(id, r, opt)
+
+	popup := ctx.getContainer(ctx.GetID([]byte(name)), MU_OPT_CLOSED)
+	open := popup != nil && popup.Open
+	opened := false
+
+	// open on click, the popup closes itself if it is clicked while open
+	if !open && ((ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id) || ctx.KeyActivated(id)) {
+		rowh := ctx.Style.Size.Y + ctx.Style.Padding*2
+		rows := mu_max(mu_min(len(items), MU_COMBO_MAX_ITEMS), 1)
+		h := rows*(rowh+ctx.Style.Spacing) - ctx.Style.Spacing + ctx.Style.Padding*2
+		ctx.OpenPopupAt(name, NewRect(r.X, r.Y+r.H, r.W, h))
+		popup = ctx.GetContainer(name)
+		popup.Scroll = NewVec2(0, 0)
+		ctx.comboHighlight = mu_clamp(*selected, 0, len(items)-1)
+		ctx.comboScrollToHighlight = true
+		open = true
+		opened = true
+	}
+
+	// draw
+	ctx.DrawControlFrame(id, r, MU_COLOR_BUTTON, opt)
+	ctx.DrawFocusRing(id, r)
+	text := label
+	if *selected >= 0 && *selected < len(items) {
+		text = items[*selected]
+	}
+	icon := MU_ICON_COLLAPSED
+	if open {
+		icon = MU_ICON_EXPANDED
+	}
+	textr := r
+	textr.W -= r.H
+	ctx.DrawControlText(text, textr, MU_COLOR_TEXT, opt)
+	ctx.DrawIcon(icon, NewRect(r.X+r.W-r.H, r.Y, r.H, r.H), ctx.Style.Colors[MU_COLOR_TEXT])
+
+	ctx.LastID = id
+	if !open {
+		return res
+	}
+
+	// the popup keeps the rect it was opened with, only follow the control
+	// in case it moved
+	popup.Rect.X = r.X
+	popup.Rect.Y = r.Y + r.H
+	popup.Rect.W = r.W
+	opts := MU_OPT_POPUP | MU_OPT_NORESIZE | MU_OPT_NOTITLE | MU_OPT_CLOSED
+	if ctx.BeginWindowEx(name, popup.Rect, opts) == 0 {
+		return res
+	}
+
+	// handle keyboard input, ignoring the keys that opened the popup
+	ctx.comboHighlight = mu_clamp(ctx.comboHighlight, 0, len(items)-1)
+	if opened {
+		ctx.KeyPressed &= ^(MU_KEY_RETURN | MU_KEY_SPACE)
+	}
+	if (ctx.KeyPressed & (MU_KEY_UP | MU_KEY_DOWN)) != 0 {
+		ctx.comboScrollToHighlight = true
+	}
+	if (ctx.KeyPressed & MU_KEY_UP) != 0 {
+		ctx.comboHighlight = mu_max(ctx.comboHighlight-1, 0)
+	}
+	if (ctx.KeyPressed & MU_KEY_DOWN) != 0 {
+		ctx.comboHighlight = mu_min(ctx.comboHighlight+1, len(items)-1)
+	}
+	keySelect := (ctx.KeyPressed & (MU_KEY_RETURN | MU_KEY_SPACE)) != 0
+	if (ctx.KeyPressed & MU_KEY_ESCAPE) != 0 {
+		popup.Open = false
+	}
+	// the keys were used by the popup, don't let navigation use them too
+	ctx.KeyPressed &= ^(MU_KEY_UP | MU_KEY_DOWN | MU_KEY_RETURN | MU_KEY_SPACE | MU_KEY_ESCAPE)
+
+	ctx.LayoutRow(1, []int{-1}, 0)
+	for i, item := range items {
+		iid := ctx.GetID([]byte("!item" + strconv.Itoa(i)))
+		ir := ctx.LayoutNext()
+		ctx.UpdateControl(iid, ir, MU_OPT_NONAV)
+		if ctx.Hover == iid && ctx.MouseDelta != (Vec2{}) {
+			ctx.comboHighlight = i
+		}
+		clicked := ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == iid
+		if clicked || (keySelect && i == ctx.comboHighlight) {
+			if *selected != i {
+				*selected = i
+				res |= MU_RES_CHANGE
+			}
+			popup.Open = false
+		}
+		// keep the highlighted item in view when it was changed by keyboard
+		if i == ctx.comboHighlight && ctx.comboScrollToHighlight {
+			ctx.comboScrollToHighlight = false
+			top := ir.Y - popup.Body.Y - ctx.Style.Padding + popup.Scroll.Y
+			viewh := popup.Body.H - ctx.Style.Padding*2
+			if top < popup.Scroll.Y {
+				popup.Scroll.Y = top
+			} else if top+ir.H > popup.Scroll.Y+viewh {
+				popup.Scroll.Y = top + ir.H - viewh
+			}
+		}
+		colorid := -1
+		if i == ctx.comboHighlight {
+			colorid = MU_COLOR_BUTTONHOVER
+		} else if i == *selected {
+			colorid = MU_COLOR_BUTTON
+		}
+		if colorid >= 0 {
+			ctx.DrawFrame(ctx, ir, colorid)
+		}
+		ctx.DrawControlText(item, ir, MU_COLOR_TEXT, 0)
+	}
+	ctx.EndPopup()
+	ctx.LastID = id
+	ctx.LastRect = r
+
+	return res
+}
@@ -0,0 +1,109 @@
+package microui
+
+import (
+	"strconv"
+	"testing"
+)
+
+// returns a ui with a single combo and a function that returns its popup
+// container, nil until it was opened
+func testComboUI(ctx *Context, selected *int, items []string) (ui func(), popup func() *Container) {
+	var cnt *Container
+	ui = func() {
+		ctx.LayoutRow(1, []int{-1}, 0)
+		ctx.Combo("combo", selected, items)
+		cnt = ctx.getContainer(ctx.GetID([]byte("combo!combo")), MU_OPT_CLOSED)
+	}
+	popup = func() *Container {
+		return cnt
+	}
+	return ui, popup
+}
+
+// code that runs right after a combo, e.g. BeginContextMenu, has to see the
+// combo's rect and id and not those of the last item in its popup
+func TestComboLastRect(t *testing.T) {
+	ctx := newTestContext()
+	selected := 0
+	items := []string{"one", "two", "three"}
+	var r, last Rect
+	var id, lastID mu_Id
+	open := false
+	ui := func() {
+		ctx.LayoutRow(1, []int{-1}, 0)
+		r = ctx.LayoutNext()
+		ctx.LayoutSetNext(r, false)
+		ctx.Combo("combo", &selected, items)
+		last, lastID = ctx.LastRect, ctx.LastID
+		id = ctx.GetID([]byte("combo"))
+		popup := ctx.getContainer(ctx.GetID([]byte("combo!combo")), MU_OPT_CLOSED)
+		open = popup != nil && popup.Open
+	}
+	testFrame(ctx, ui)
+	testClick(ctx, ui, Vec2{r.X + 5, r.Y + 5})
+	if !open {
+		t.Fatal("combo didn't open")
+	}
+	if last != r || lastID != id {
+		t.Errorf("LastRect = %v, LastID = %v after an open combo, want %v, %v", last, lastID, r, id)
+	}
+}
+
+func TestComboKeyboard(t *testing.T) {
+	ctx := newTestContext()
+	selected := 0
+	ui, popup := testComboUI(ctx, &selected, []string{"one", "two", "three"})
+	open := func() bool { return popup() != nil && popup().Open }
+	testFrame(ctx, ui)
+	testKey(ctx, ui, MU_KEY_TAB)
+
+	// return opens the popup with the selected item highlighted, without
+	// selecting it again
+	testKey(ctx, ui, MU_KEY_RETURN)
+	if !open() {
+		t.Fatal("return didn't open the combo")
+	}
+	testKey(ctx, ui, MU_KEY_DOWN)
+	testKey(ctx, ui, MU_KEY_DOWN)
+	testKey(ctx, ui, MU_KEY_DOWN) // stops at the last item
+	testKey(ctx, ui, MU_KEY_UP)
+	if selected != 0 {
+		t.Errorf("moving the highlight changed the selection to %d", selected)
+	}
+	testKey(ctx, ui, MU_KEY_SPACE)
+	if selected != 1 || open() {
+		t.Errorf("space gave selected = %d, open = %v, want 1, false", selected, open())
+	}
+
+	// escape closes the popup without changing the selection
+	testKey(ctx, ui, MU_KEY_RETURN)
+	testKey(ctx, ui, MU_KEY_DOWN)
+	testKey(ctx, ui, MU_KEY_ESCAPE)
+	if selected != 1 || open() {
+		t.Errorf("escape gave selected = %d, open = %v, want 1, false", selected, open())
+	}
+}
+
+// moving the highlight past the visible items scrolls the popup
+func TestComboKeyboardScroll(t *testing.T) {
+	ctx := newTestContext()
+	selected := 0
+	var items []string
+	for i := 0; i < MU_COMBO_MAX_ITEMS*2; i++ {
+		items = append(items, "item "+strconv.Itoa(i))
+	}
+	ui, popup := testComboUI(ctx, &selected, items)
+	testFrame(ctx, ui)
+	testKey(ctx, ui, MU_KEY_TAB)
+	testKey(ctx, ui, MU_KEY_RETURN)
+	for i := 0; i < len(items); i++ {
+		testKey(ctx, ui, MU_KEY_DOWN)
+	}
+	if popup() == nil || popup().Scroll.Y == 0 {
+		t.Errorf("the popup didn't scroll to the last item")
+	}
+	testKey(ctx, ui, MU_KEY_RETURN)
+	if selected != len(items)-1 || popup().Open {
+		t.Errorf("return gave selected = %d, open = %v, want %d, false", selected, popup().Open, len(items)-1)
+	}
+}
@@ -11,9 +11,16 @@ const (
 	MU_LAYOUTSTACK_SIZE    = 16
 	MU_CONTAINERPOOL_SIZE  = 48
 	MU_TREENODEPOOL_SIZE   = 48
+	MU_TEXTUNDOPOOL_SIZE   = 16
+	MU_TABBARPOOL_SIZE     = 16
+	MU_TABLEPOOL_SIZE      = 16
+	MU_UNDOSTACK_SIZE      = 128
 	MU_MAX_WIDTHS          = 16
+	MU_COMBO_MAX_ITEMS     = 8 // number of items visible in a combo popup
 )
 
+const MU_TOOLTIP_DELAY = 0.5 // default for ctx.TooltipDelay, in seconds
+
 const (
 	MU_REAL_FMT   = "%.3g"
 	MU_SLIDER_FMT = "%.2f"
@@ -29,7 +36,7 @@ var default_style Style = Style{
 	TitleHeight:   24,
 	ScrollbarSize: 12,
 	ThumbSize:     8,
-	Colors: [14]Color{
+	Colors: [MU_COLOR_MAX]Color{
 		{230, 230, 230, 255}, // MU_COLOR_TEXT
 		{25, 25, 25, 255},    // MU_COLOR_BORDER
 		{50, 50, 50, 255},    // MU_COLOR_WINDOWBG
@@ -44,6 +51,7 @@ var default_style Style = Style{
 		{40, 40, 40, 255},    // MU_COLOR_BASEFOCUS
 		{43, 43, 43, 255},    // MU_COLOR_SCROLLBASE
 		{30, 30, 30, 255},    // MU_COLOR_SCROLLTHUMB
+		{90, 140, 210, 255},  // MU_COLOR_FOCUSRING
 	},
 }
 
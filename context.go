@@ -18,6 +18,7 @@ func initContext(ctx *Context) {
 	ctx.DrawFrame = drawFrame
 	ctx._style = default_style
 	ctx.Style = &ctx._style
+	ctx.TooltipDelay = MU_TOOLTIP_DELAY
 }
 
 func NewContext() *Context {
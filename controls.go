@@ -1,10 +1,6 @@
 package microui
 
-import (
-	"fmt"
-	"strconv"
-	"unsafe"
-)
+import "unsafe"
 
 /*============================================================================
 ** controls
@@ -68,6 +64,7 @@ func (ctx *Context) UpdateControl(id mu_Id, rect Rect, opt int) {
 	if (opt & MU_OPT_NOINTERACT) != 0 {
 		return
 	}
+	ctx.addNavItem(id, rect, opt)
 	if mouseover && ctx.MouseDown == 0 {
 		ctx.Hover = id
 	}
@@ -76,7 +73,7 @@ func (ctx *Context) UpdateControl(id mu_Id, rect Rect, opt int) {
 		if ctx.MousePressed != 0 && !mouseover {
 			ctx.SetFocus(0)
 		}
-		if ctx.MouseDown == 0 && (^opt&MU_OPT_HOLDFOCUS) != 0 {
+		if ctx.MouseDown == 0 && (^opt&MU_OPT_HOLDFOCUS) != 0 && !ctx.KeyboardFocus {
 			ctx.SetFocus(0)
 		}
 	}
@@ -84,6 +81,7 @@ func (ctx *Context) UpdateControl(id mu_Id, rect Rect, opt int) {
 	if ctx.Hover == id {
 		if ctx.MousePressed != 0 {
 			ctx.SetFocus(id)
+			ctx.KeyboardFocus = false
 		} else if !mouseover {
 			ctx.Hover = 0
 		}
@@ -111,14 +109,24 @@ func (ctx *Context) Text(text string) {
 			if w > r.W && end_idx != start_idx {
 				break
 			}
+			if w > r.W {
+				// the word doesn't fit on a line of its own (which is common
+				// for scripts without spaces), break it after the last
+				// grapheme cluster that fits
+				end_idx = ctx.textFitIndex(font, text, word, p, r.W)
+				break
+			}
 			if p < len(text) {
-				w += ctx.TextWidth(font, string(text[p]))
+				w += ctx.TextWidth(font, text[p:p+1])
 			}
 			end_idx = p
 			p++
 		}
 		ctx.DrawText(font, text[start_idx:end_idx], NewVec2(r.X, r.Y), color)
-		p = end_idx + 1
+		p = end_idx
+		if p < len(text) && (text[p] == ' ' || text[p] == '\n') {
+			p++
+		}
 	}
 	ctx.LayoutEndColumn()
 }
@@ -138,11 +146,12 @@ func (ctx *Context) ButtonEx(label string, icon int, opt int) int {
 	r := ctx.LayoutNext()
 	ctx.UpdateControl(id, r, opt)
 	// handle click
-	if ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id {
+	if (ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id) || ctx.KeyActivated(id) {
 		res |= MU_RES_SUBMIT
 	}
 	// draw
 	ctx.DrawControlFrame(id, r, MU_COLOR_BUTTON, opt)
+	ctx.DrawFocusRing(id, r)
 	if len(label) > 0 {
 		ctx.DrawControlText(label, r, MU_COLOR_TEXT, opt)
 	}
@@ -159,12 +168,15 @@ func (ctx *Context) Checkbox(label string, state *bool) int {
 	box := NewRect(r.X, r.Y, r.H, r.H)
 	ctx.UpdateControl(id, r, 0)
 	// handle click
-	if ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id {
+	if (ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id) || ctx.KeyActivated(id) {
 		res |= MU_RES_CHANGE
 		*state = !*state
+		v := *state
+		ctx.PushUndo(func() { *state = !v }, func() { *state = v })
 	}
 	// draw
 	ctx.DrawControlFrame(id, box, MU_COLOR_BASE, 0)
+	ctx.DrawFocusRing(id, box)
 	if *state {
 		ctx.DrawIcon(MU_ICON_CHECK, box, ctx.Style.Colors[MU_COLOR_TEXT])
 	}
@@ -173,42 +185,50 @@ func (ctx *Context) Checkbox(label string, state *bool) int {
 	return res
 }
 
+// returns the x position the text of a focused textbox is drawn at so that
+// the caret at byte offset cursor stays visible
+func (ctx *Context) textboxTextX(buf string, r Rect, cursor int) int {
+	caretx := ctx.TextWidth(ctx.Style.Font, buf[:mu_clamp(cursor, 0, len(buf))])
+	ofx := r.W - ctx.Style.Padding - caretx - 1
+	return r.X + mu_min(ofx, ctx.Style.Padding)
+}
+
 func (ctx *Context) TextboxRaw(buf *string, id mu_Id, r Rect, opt int) int {
 	var res int = 0
 	ctx.UpdateControl(id, r, opt|MU_OPT_HOLDFOCUS)
-	buflen := len(*buf)
 
 	if ctx.Focus == id {
-		// handle text input
-		if len(ctx.TextInput) > 0 {
-			*buf += string(ctx.TextInput)
-			res |= MU_RES_CHANGE
-		}
-		// handle backspace
-		if (ctx.KeyPressed&MU_KEY_BACKSPACE) != 0 && buflen > 0 {
-			*buf = (*buf)[:buflen-1]
-			res |= MU_RES_CHANGE
-		}
-		// handle return
-		if (ctx.KeyPressed & MU_KEY_RETURN) != 0 {
-			ctx.SetFocus(0)
-			res |= MU_RES_SUBMIT
+		cursor := len(*buf)
+		if ctx.TextEdit == id {
+			cursor = ctx.TextCursor
 		}
+		textx := ctx.textboxTextX(*buf, r, cursor)
+		res = ctx.textEdit(buf, id, r, func(pos Vec2) int {
+			return ctx.textIndexAt(ctx.Style.Font, *buf, pos.X-textx)
+		}, nil)
+	} else if ctx.TextEdit == id {
+		ctx.TextEdit = 0
 	}
 
 	// draw
 	ctx.DrawControlFrame(id, r, MU_COLOR_BASE, opt)
+	ctx.DrawFocusRing(id, r)
 	if ctx.Focus == id {
 		color := ctx.Style.Colors[MU_COLOR_TEXT]
 		font := ctx.Style.Font
-		textw := ctx.TextWidth(font, *buf)
 		texth := ctx.TextHeight(font)
-		ofx := r.W - ctx.Style.Padding - textw - 1
-		textx := r.X + mu_min(ofx, ctx.Style.Padding)
+		textx := ctx.textboxTextX(*buf, r, ctx.TextCursor)
 		texty := r.Y + (r.H-texth)/2
+		lo, hi := ctx.textSelection(*buf)
 		ctx.PushClipRect(r)
+		if lo != hi {
+			selx := textx + ctx.TextWidth(font, (*buf)[:lo])
+			selw := ctx.TextWidth(font, (*buf)[lo:hi])
+			ctx.DrawRect(NewRect(selx, texty, selw, texth), ctx.Style.Colors[MU_COLOR_BUTTONFOCUS])
+		}
 		ctx.DrawText(font, *buf, NewVec2(textx, texty), color)
-		ctx.DrawRect(NewRect(textx+textw, texty, 1, texth), color)
+		caretx := textx + ctx.TextWidth(font, (*buf)[:ctx.TextCursor])
+		ctx.DrawRect(NewRect(caretx, texty, 1, texth), color)
 		ctx.PopClipRect()
 	} else {
 		ctx.DrawControlText(*buf, r, MU_COLOR_TEXT, opt)
@@ -218,25 +238,7 @@ func (ctx *Context) TextboxRaw(buf *string, id mu_Id, r Rect, opt int) int {
 }
 
 func (ctx *Context) NumberTextBox(value *float32, r Rect, id mu_Id) bool {
-	if ctx.MousePressed == MU_MOUSE_LEFT && (ctx.KeyDown&MU_KEY_SHIFT) != 0 &&
-		ctx.Hover == id {
-		ctx.NumberEdit = id
-		ctx.NumberEditBuf = fmt.Sprintf(MU_REAL_FMT, *value)
-	}
-	if ctx.NumberEdit == id {
-		res := ctx.TextboxRaw(&ctx.NumberEditBuf, id, r, 0)
-		if (res&MU_RES_SUBMIT) != 0 || ctx.Focus != id {
-			nval, err := strconv.ParseFloat(ctx.NumberEditBuf, 32)
-			if err != nil {
-				nval = 0
-			}
-			*value = float32(nval)
-			ctx.NumberEdit = 0
-		} else {
-			return true
-		}
-	}
-	return false
+	return numberTextBox(ctx, value, r, id)
 }
 
 func (ctx *Context) TextBoxEx(buf *string, opt int) int {
@@ -245,90 +247,113 @@ func (ctx *Context) TextBoxEx(buf *string, opt int) int {
 	return ctx.TextboxRaw(buf, id, r, opt)
 }
 
-func (ctx *Context) SliderEx(value *float32, low float32, high float32, step float32, format string, opt int) int {
-	var thumb Rect
-	var x, w, res int = 0, 0, 0
-	last := *value
-	v := last
-	id := ctx.GetID(unsafe.Slice((*byte)(unsafe.Pointer(&value)), unsafe.Sizeof(value)))
-	base := ctx.LayoutNext()
-
-	// handle text input mode
-	if ctx.NumberTextBox(&v, base, id) {
-		return res
-	}
-
-	// handle normal mode
-	ctx.UpdateControl(id, base, opt)
-
-	// handle input
-	if ctx.Focus == id && (ctx.MouseDown|ctx.MousePressed) == MU_MOUSE_LEFT {
-		v = low + float32(ctx.MousePos.X-base.X)*(high-low)/float32(base.W)
-		if step != 0 {
-			v = float32(int64((v+step/2)/step)) * step
-		}
-	}
-	// clamp and store value, update res
-	*value = mu_clamp_real(v, low, high)
-	v = *value
-	if last != v {
-		res |= MU_RES_CHANGE
-	}
-
-	// draw base
-	ctx.DrawControlFrame(id, base, MU_COLOR_BASE, opt)
-	// draw thumb
-	w = ctx.Style.ThumbSize
-	x = int((v - low) * float32(base.W-w) / (high - low))
-	thumb = NewRect(base.X+x, base.Y, w, base.H)
-	ctx.DrawControlFrame(id, thumb, MU_COLOR_BUTTON, opt)
-	// draw text
-	text := fmt.Sprintf(format, v)
-	ctx.DrawControlText(text, base, MU_COLOR_TEXT, opt)
+func (ctx *Context) TextAreaEx(buf *string, opt int) int {
+	var res int = 0
+	id := ctx.GetID(unsafe.Slice((*byte)(unsafe.Pointer(&buf)), unsafe.Sizeof(buf)))
+	r := ctx.LayoutNext()
+	font := ctx.Style.Font
+	lineh := ctx.TextHeight(font)
 
-	return res
-}
+	// the text area is a container so that it can be scrolled
+	cnt := ctx.getContainer(id, 0)
+	cnt.Rect = r
+	ctx.DrawControlFrame(id, r, MU_COLOR_BASE, opt)
+	ctx.DrawFocusRing(id, r)
+	// push()
+	ctx.ContainerStack = append(ctx.ContainerStack, cnt)
+	// push()
+	ctx.IdStack = append(ctx.IdStack, id)
+	ctx.PushContainerBody(cnt, r, opt)
+	ctx.UpdateControl(id, cnt.Body, opt|MU_OPT_HOLDFOCUS)
 
-func (ctx *Context) NumberEx(value *float32, step float32, format string, opt int) int {
-	var res int = 0
-	id := ctx.GetID(unsafe.Slice((*byte)(unsafe.Pointer(&value)), unsafe.Sizeof(value)))
-	base := ctx.LayoutNext()
-	last := *value
+	// lay out the wrapped lines as a single item
+	width := ctx.GetLayout().Body.W
+	lines := ctx.textWrapLines(font, *buf, width)
+	ctx.LayoutRow(1, []int{-1}, len(lines)*lineh)
+	content := ctx.LayoutNext()
 
-	// handle text input mode
-	if ctx.NumberTextBox(value, base, id) {
-		return res
+	if ctx.Focus == id {
+		res = ctx.textEdit(buf, id, cnt.Body, func(pos Vec2) int {
+			li := mu_clamp((pos.Y-content.Y)/lineh, 0, len(lines)-1)
+			line := (*buf)[lines[li].Start:lines[li].End]
+			return lines[li].Start + ctx.textIndexAt(font, line, pos.X-content.X)
+		}, func() []textLine {
+			return ctx.textWrapLines(font, *buf, width)
+		})
+		if res != 0 {
+			lines = ctx.textWrapLines(font, *buf, width)
+		}
+		// scroll to keep the caret visible after it was moved
+		if ctx.KeyPressed != 0 || len(ctx.TextInput) > 0 || ctx.MouseDown == MU_MOUSE_LEFT {
+			carety := textLineOf(lines, ctx.TextCursor) * lineh
+			scrollh := cnt.Body.H - ctx.Style.Padding*2
+			if carety < cnt.Scroll.Y {
+				cnt.Scroll.Y = carety
+			} else if carety+lineh > cnt.Scroll.Y+scrollh {
+				cnt.Scroll.Y = carety + lineh - scrollh
+			}
+		}
+	} else if ctx.TextEdit == id {
+		ctx.TextEdit = 0
 	}
 
-	// handle normal mode
-	ctx.UpdateControl(id, base, opt)
-
-	// handle input
-	if ctx.Focus == id && ctx.MouseDown == MU_MOUSE_LEFT {
-		*value += float32(ctx.MouseDelta.X) * step
+	// draw
+	color := ctx.Style.Colors[MU_COLOR_TEXT]
+	lo, hi := ctx.textSelection(*buf)
+	ctx.PushClipRect(cnt.Body)
+	for i, l := range lines {
+		y := content.Y + i*lineh
+		if y+lineh < cnt.Body.Y || y > cnt.Body.Y+cnt.Body.H {
+			continue
+		}
+		line := (*buf)[l.Start:l.End]
+		if ctx.Focus == id && lo < l.End && hi > l.Start {
+			s := mu_max(lo, l.Start)
+			e := mu_min(hi, l.End)
+			selx := content.X + ctx.TextWidth(font, (*buf)[l.Start:s])
+			selw := ctx.TextWidth(font, (*buf)[s:e])
+			ctx.DrawRect(NewRect(selx, y, selw, lineh), ctx.Style.Colors[MU_COLOR_BUTTONFOCUS])
+		}
+		ctx.DrawText(font, line, NewVec2(content.X, y), color)
 	}
-	// set flag if value changed
-	if *value != last {
-		res |= MU_RES_CHANGE
+	if ctx.Focus == id {
+		l := lines[textLineOf(lines, ctx.TextCursor)]
+		caretx := content.X + ctx.TextWidth(font, (*buf)[l.Start:mu_clamp(ctx.TextCursor, l.Start, l.End)])
+		carety := content.Y + textLineOf(lines, ctx.TextCursor)*lineh
+		ctx.DrawRect(NewRect(caretx, carety, 1, lineh), color)
 	}
-
-	// draw base
-	ctx.DrawControlFrame(id, base, MU_COLOR_BASE, opt)
-	// draw text
-	text := fmt.Sprintf(format, *value)
-	ctx.DrawControlText(text, base, MU_COLOR_TEXT, opt)
+	ctx.PopClipRect()
+	ctx.PopContainer()
 
 	return res
 }
 
+func (ctx *Context) SliderEx(value *float32, low float32, high float32, step float32, format string, opt int) int {
+	return SliderT(ctx, value, low, high, step, format, opt)
+}
+
+func (ctx *Context) NumberEx(value *float32, step float32, format string, opt int) int {
+	return NumberT(ctx, value, step, format, opt)
+}
+
+// does a header or tree node. MU_OPT_LEAF nodes have no arrow and can't be
+// expanded. with MU_OPT_SELECTABLE clicks on the row are reported by
+// TreeNodeClicked and MU_OPT_SELECTED draws it highlighted. the mouse only
+// expands or collapses MU_OPT_OPENONARROW nodes when the arrow is clicked
 func (ctx *Context) MuHeader(label string, istreenode bool, opt int) int {
 	var r Rect
 	var active, expanded bool
+	var res int = 0
 	id := ctx.GetID([]byte(label))
 	idx := ctx.PoolGet(ctx.TreeNodePool[:], id)
 	ctx.LayoutRow(1, []int{-1}, 0)
 
 	active = idx >= 0
+	// apply the state set by SetNextTreeNodeOpen
+	if ctx.nextTreeNodeOpen != 0 {
+		active = (ctx.nextTreeNodeOpen == 1) != ((opt & MU_OPT_EXPANDED) != 0)
+		ctx.nextTreeNodeOpen = 0
+	}
 	if (opt & MU_OPT_EXPANDED) != 0 {
 		expanded = !active
 	} else {
@@ -338,12 +363,21 @@ func (ctx *Context) MuHeader(label string, istreenode bool, opt int) int {
 	ctx.UpdateControl(id, r, 0)
 
 	// handle click (TODO (port): check if this is correct)
-	clicked := ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id
+	pressed := ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id
+	clicked := pressed || ctx.KeyActivated(id)
+	arrow := (opt&MU_OPT_OPENONARROW) != 0 && pressed &&
+		rect_overlaps_vec2(NewRect(r.X, r.Y, r.H, r.H), ctx.MousePos)
+	toggled := clicked && (opt&MU_OPT_LEAF) == 0
+	if (opt & MU_OPT_OPENONARROW) != 0 {
+		// return and space still toggle the node from the keyboard
+		toggled = toggled && (arrow || !pressed)
+	}
+	ctx.treeNodeClicked = clicked && (opt&MU_OPT_SELECTABLE) != 0 && !arrow
 	v1, v2 := 0, 0
 	if active {
 		v1 = 1
 	}
-	if clicked {
+	if toggled {
 		v2 = 1
 	}
 	active = (v1 ^ v2) == 1
@@ -360,32 +394,54 @@ func (ctx *Context) MuHeader(label string, istreenode bool, opt int) int {
 	}
 
 	// draw
-	if istreenode {
+	if (opt & MU_OPT_SELECTED) != 0 {
+		ctx.DrawFrame(ctx, r, MU_COLOR_BUTTONFOCUS)
+	} else if istreenode {
 		if ctx.Hover == id {
 			ctx.DrawFrame(ctx, r, MU_COLOR_BUTTONHOVER)
 		}
 	} else {
 		ctx.DrawControlFrame(id, r, MU_COLOR_BUTTON, 0)
 	}
-	var icon_id int
-	if expanded {
-		icon_id = MU_ICON_EXPANDED
-	} else {
-		icon_id = MU_ICON_COLLAPSED
+	ctx.DrawFocusRing(id, r)
+	if (opt & MU_OPT_LEAF) == 0 {
+		var icon_id int
+		if expanded {
+			icon_id = MU_ICON_EXPANDED
+		} else {
+			icon_id = MU_ICON_COLLAPSED
+		}
+		ctx.DrawIcon(
+			icon_id,
+			NewRect(r.X, r.Y, r.H, r.H),
+			ctx.Style.Colors[MU_COLOR_TEXT],
+		)
 	}
-	ctx.DrawIcon(
-		icon_id,
-		NewRect(r.X, r.Y, r.H, r.H),
-		ctx.Style.Colors[MU_COLOR_TEXT],
-	)
 	r.X += r.H - ctx.Style.Padding
 	r.W -= r.H - ctx.Style.Padding
 	ctx.DrawControlText(label, r, MU_COLOR_TEXT, 0)
 
-	if expanded {
-		return MU_RES_ACTIVE
+	if expanded && (opt&MU_OPT_LEAF) == 0 {
+		res |= MU_RES_ACTIVE
 	}
-	return 0
+	return res
+}
+
+// sets whether the next header or tree node is expanded, e.g. to reveal a
+// node whose parents are collapsed
+func (ctx *Context) SetNextTreeNodeOpen(open bool) {
+	if open {
+		ctx.nextTreeNodeOpen = 1
+	} else {
+		ctx.nextTreeNodeOpen = 2
+	}
+}
+
+// reports whether the row of the last header or tree node was clicked or
+// activated with the keyboard. only set for MU_OPT_SELECTABLE nodes, clicks
+// on the arrow of MU_OPT_OPENONARROW nodes don't count
+func (ctx *Context) TreeNodeClicked() bool {
+	return ctx.treeNodeClicked
 }
 
 func (ctx *Context) HeaderEx(label string, opt int) int {
@@ -420,7 +476,7 @@ func (ctx *Context) scrollbarVertical(cnt *Container, b *Rect, cs Vec2) {
 		base.W = ctx.Style.ScrollbarSize
 
 		// handle input
-		ctx.UpdateControl(id, base, 0)
+		ctx.UpdateControl(id, base, MU_OPT_NONAV)
 		if ctx.Focus == id && ctx.MouseDown == MU_MOUSE_LEFT {
 			cnt.Scroll.Y += ctx.MouseDelta.Y * cs.Y / base.H
 		}
@@ -457,7 +513,7 @@ func (ctx *Context) scrollbarHorizontal(cnt *Container, b *Rect, cs Vec2) {
 		base.H = ctx.Style.ScrollbarSize
 
 		// handle input
-		ctx.UpdateControl(id, base, 0)
+		ctx.UpdateControl(id, base, MU_OPT_NONAV)
 		if ctx.Focus == id && ctx.MouseDown == MU_MOUSE_LEFT {
 			cnt.Scroll.X += ctx.MouseDelta.X * cs.X / base.W
 		}
@@ -580,7 +636,7 @@ func (ctx *Context) BeginWindowEx(title string, rect Rect, opt int) int {
 		// do title text
 		if (^opt & MU_OPT_NOTITLE) != 0 {
 			id := ctx.GetID([]byte("!title"))
-			ctx.UpdateControl(id, tr, opt)
+			ctx.UpdateControl(id, tr, opt|MU_OPT_NONAV)
 			ctx.DrawControlText(title, tr, MU_COLOR_TITLETEXT, opt)
 			if id == ctx.Focus && ctx.MouseDown == MU_MOUSE_LEFT {
 				cnt.Rect.X += ctx.MouseDelta.X
@@ -596,7 +652,7 @@ func (ctx *Context) BeginWindowEx(title string, rect Rect, opt int) int {
 			r := NewRect(tr.X+tr.W-tr.H, tr.Y, tr.H, tr.H)
 			tr.W -= r.W
 			ctx.DrawIcon(MU_ICON_CLOSE, r, ctx.Style.Colors[MU_COLOR_TITLETEXT])
-			ctx.UpdateControl(id, r, opt)
+			ctx.UpdateControl(id, r, opt|MU_OPT_NONAV)
 			if ctx.MousePressed == MU_MOUSE_LEFT && id == ctx.Focus {
 				cnt.Open = false
 			}
@@ -610,7 +666,7 @@ func (ctx *Context) BeginWindowEx(title string, rect Rect, opt int) int {
 		sz := ctx.Style.TitleHeight
 		id := ctx.GetID([]byte("!resize"))
 		r := NewRect(rect.X+rect.W-sz, rect.Y+rect.H-sz, sz, sz)
-		ctx.UpdateControl(id, r, opt)
+		ctx.UpdateControl(id, r, opt|MU_OPT_NONAV)
 		if id == ctx.Focus && ctx.MouseDown == MU_MOUSE_LEFT {
 			cnt.Rect.W = mu_max(96, cnt.Rect.W+ctx.MouseDelta.X)
 			cnt.Rect.H = mu_max(64, cnt.Rect.H+ctx.MouseDelta.Y)
@@ -639,12 +695,18 @@ func (ctx *Context) EndWindow() {
 }
 
 func (ctx *Context) OpenPopup(name string) {
+	ctx.OpenPopupAt(name, NewRect(ctx.MousePos.X, ctx.MousePos.Y, 1, 1))
+}
+
+// opens the popup with the given rect instead of at the mouse cursor. popups
+// begun with BeginPopup are resized to their content
+func (ctx *Context) OpenPopupAt(name string, rect Rect) {
 	cnt := ctx.GetContainer(name)
 	// set as hover root so popup isn't closed in begin_window_ex()
 	ctx.NextHoverRoot = cnt
 	ctx.HoverRoot = ctx.NextHoverRoot
-	// position at mouse cursor, open and bring-to-front
-	cnt.Rect = NewRect(ctx.MousePos.X, ctx.MousePos.Y, 1, 1)
+	// position, open and bring-to-front
+	cnt.Rect = rect
 	cnt.Open = true
 	ctx.BringToFront(cnt)
 }
@@ -0,0 +1,92 @@
+package microui
+
+import "testing"
+
+// runs a frame with ui inside a window
+func testFrame(ctx *Context, ui func()) {
+	ctx.Begin()
+	if ctx.BeginWindow("Window", NewRect(0, 0, 200, 200)) {
+		ui()
+		ctx.EndWindow()
+	}
+	ctx.End()
+}
+
+// clicks the point p, taking the frames microui needs to update the hover
+// and focus state
+func testClick(ctx *Context, ui func(), p Vec2) {
+	ctx.InputMouseMove(p.X, p.Y)
+	testFrame(ctx, ui)
+	testFrame(ctx, ui)
+	ctx.InputMouseDown(p.X, p.Y, MU_MOUSE_LEFT)
+	testFrame(ctx, ui)
+	ctx.InputMouseUp(p.X, p.Y, MU_MOUSE_LEFT)
+	testFrame(ctx, ui)
+}
+
+func testKey(ctx *Context, ui func(), key int) {
+	ctx.InputKeyDown(key)
+	testFrame(ctx, ui)
+	ctx.InputKeyUp(key)
+	testFrame(ctx, ui)
+}
+
+func TestTreeNodeSelectable(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     int
+		arrow   bool // click the arrow instead of the label
+		open    bool
+		clicked bool
+	}{
+		{"leaf", MU_OPT_LEAF | MU_OPT_SELECTABLE, false, false, true},
+		{"node", MU_OPT_SELECTABLE, false, true, true},
+		{"not selectable", 0, false, true, false},
+		{"open on arrow label", MU_OPT_OPENONARROW | MU_OPT_SELECTABLE, false, false, true},
+		{"open on arrow arrow", MU_OPT_OPENONARROW | MU_OPT_SELECTABLE, true, true, false},
+	}
+	for _, tt := range tests {
+		ctx := newTestContext()
+		var r Rect
+		var open, clicked bool
+		ui := func() {
+			open = ctx.BeginTreeNodeEx("node", tt.opt) != 0
+			r = ctx.LastRect
+			clicked = clicked || ctx.TreeNodeClicked()
+			if open {
+				ctx.Label("child")
+				ctx.EndTreeNode()
+			}
+		}
+		testFrame(ctx, ui)
+		p := Vec2{r.X + r.W/2, r.Y + r.H/2}
+		if tt.arrow {
+			p.X = r.X + r.H/2
+		}
+		testClick(ctx, ui, p)
+		if open != tt.open || clicked != tt.clicked {
+			t.Errorf("%s: open = %v, clicked = %v, want %v, %v", tt.name, open, clicked, tt.open, tt.clicked)
+		}
+	}
+}
+
+func TestTreeNodeOpenOnArrowKeyboard(t *testing.T) {
+	ctx := newTestContext()
+	var open bool
+	ui := func() {
+		open = ctx.BeginTreeNodeEx("node", MU_OPT_OPENONARROW) != 0
+		if open {
+			ctx.EndTreeNode()
+		}
+	}
+	testFrame(ctx, ui)
+	testKey(ctx, ui, MU_KEY_TAB)
+	testKey(ctx, ui, MU_KEY_RETURN)
+	if !open {
+		t.Errorf("return didn't open the node")
+	}
+	testKey(ctx, ui, MU_KEY_SPACE)
+	if open {
+		t.Errorf("space didn't close the node")
+	}
+}
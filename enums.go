@@ -29,6 +29,7 @@ const (
 	MU_COLOR_BASEFOCUS
 	MU_COLOR_SCROLLBASE
 	MU_COLOR_SCROLLTHUMB
+	MU_COLOR_FOCUSRING
 	MU_COLOR_MAX
 )
 
@@ -37,6 +38,8 @@ const (
 	MU_ICON_CHECK
 	MU_ICON_COLLAPSED
 	MU_ICON_EXPANDED
+	MU_ICON_RADIO
+	MU_ICON_RADIOCHECK
 	MU_ICON_MAX
 )
 
@@ -60,6 +63,14 @@ const (
 	MU_OPT_POPUP       = (1 << 10)
 	MU_OPT_CLOSED      = (1 << 11)
 	MU_OPT_EXPANDED    = (1 << 12)
+	MU_OPT_NONAV       = (1 << 13)
+	MU_OPT_REORDERABLE = (1 << 14)
+	MU_OPT_VERTICAL    = (1 << 15)
+	MU_OPT_SORTABLE    = (1 << 16)
+	MU_OPT_LEAF        = (1 << 17)
+	MU_OPT_SELECTABLE  = (1 << 18)
+	MU_OPT_OPENONARROW = (1 << 19)
+	MU_OPT_SELECTED    = (1 << 20)
 )
 
 const (
@@ -74,9 +85,36 @@ const (
 	MU_KEY_ALT       = (1 << 2)
 	MU_KEY_BACKSPACE = (1 << 3)
 	MU_KEY_RETURN    = (1 << 4)
+	MU_KEY_LEFT      = (1 << 5)
+	MU_KEY_RIGHT     = (1 << 6)
+	MU_KEY_HOME      = (1 << 7)
+	MU_KEY_END       = (1 << 8)
+	MU_KEY_DELETE    = (1 << 9)
+	MU_KEY_UP        = (1 << 10)
+	MU_KEY_DOWN      = (1 << 11)
+	MU_KEY_A         = (1 << 12)
+	MU_KEY_C         = (1 << 13)
+	MU_KEY_V         = (1 << 14)
+	MU_KEY_X         = (1 << 15)
+	MU_KEY_Y         = (1 << 16)
+	MU_KEY_Z         = (1 << 17)
+	MU_KEY_TAB       = (1 << 18)
+	MU_KEY_SPACE     = (1 << 19)
+	MU_KEY_ESCAPE    = (1 << 20)
 )
 
 const (
 	RELATIVE = 1 + iota
 	ABSOLUTE
 )
+
+const (
+	MU_INPUT_MOUSEMOVE = 1 + iota
+	MU_INPUT_MOUSEDOWN
+	MU_INPUT_MOUSEUP
+	MU_INPUT_SCROLL
+	MU_INPUT_KEYDOWN
+	MU_INPUT_KEYUP
+	MU_INPUT_TEXT
+	MU_INPUT_TIME
+)
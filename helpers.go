@@ -225,10 +225,12 @@ func (ctx *Context) Begin() {
 	ctx.CommandList = ctx.CommandList[:0]
 	ctx.RootList = ctx.RootList[:0]
 	ctx.ScrollTarget = nil
+	ctx.navList = ctx.navList[:0]
 	ctx.HoverRoot = ctx.NextHoverRoot
 	ctx.NextHoverRoot = nil
 	ctx.MouseDelta.X = ctx.MousePos.X - ctx.lastMousePos.X
 	ctx.MouseDelta.Y = ctx.MousePos.Y - ctx.lastMousePos.Y
+	ctx.Time += float64(ctx.DeltaTime)
 	ctx.Frame++
 }
 
@@ -238,6 +240,9 @@ func (ctx *Context) End() {
 	expect(len(ctx.ClipStack) == 0)
 	expect(len(ctx.IdStack) == 0)
 	expect(len(ctx.LayoutStack) == 0)
+	expect(len(ctx.TabBarStack) == 0)
+	expect(len(ctx.TableStack) == 0)
+	expect(len(ctx.menuStack) == 0)
 
 	// handle scroll input
 	if ctx.ScrollTarget != nil {
@@ -245,10 +250,37 @@ func (ctx *Context) End() {
 		ctx.ScrollTarget.Scroll.Y += ctx.ScrollDelta.Y
 	}
 
+	if ctx.undoLate != nil {
+		ctx.undoLate()
+		ctx.undoLate = nil
+	}
+	for _, e := range ctx.undoQueue {
+		ctx.pushUndo(e)
+	}
+	ctx.undoQueue = ctx.undoQueue[:0]
+
+	// handle undo and redo, unless a text control is focused which has its own
+	// history
+	if dir := ctx.undoKey(); dir != 0 && (ctx.Focus == 0 || ctx.Focus != ctx.TextEdit) {
+		if dir == 1 {
+			ctx.Undo()
+		} else {
+			ctx.Redo()
+		}
+	}
+
+	ctx.handleMenuClose()
+	ctx.updateHoverTime()
+
 	// unset focus if focus id was not touched this frame
 	if !ctx.UpdatedFocus {
 		ctx.Focus = 0
 	}
+	if ctx.Focus == 0 {
+		ctx.KeyboardFocus = false
+	}
+	ctx.handleTabNavigation()
+	ctx.handleDirectionalNavigation()
 	ctx.UpdatedFocus = false
 
 	// bring hover root to front if mouse was pressed
@@ -263,6 +295,7 @@ func (ctx *Context) End() {
 	ctx.TextInput = nil
 	ctx.MousePressed = 0
 	ctx.ScrollDelta = NewVec2(0, 0)
+	ctx.DeltaTime = 0
 	ctx.lastMousePos = ctx.MousePos
 
 	// sort root containers by zindex
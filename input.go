@@ -5,34 +5,49 @@ package microui
 **============================================================================*/
 
 func (ctx *Context) InputMouseMove(x, y int) {
+	ctx.record(InputEvent{Type: MU_INPUT_MOUSEMOVE, X: x, Y: y})
 	ctx.MousePos = NewVec2(x, y)
 }
 
 func (ctx *Context) InputMouseDown(x, y int, btn int) {
-	ctx.InputMouseMove(x, y)
+	ctx.record(InputEvent{Type: MU_INPUT_MOUSEDOWN, X: x, Y: y, Button: btn})
+	ctx.MousePos = NewVec2(x, y)
 	ctx.MouseDown |= btn
 	ctx.MousePressed |= btn
 }
 
 func (ctx *Context) InputMouseUp(x, y int, btn int) {
-	ctx.InputMouseMove(x, y)
+	ctx.record(InputEvent{Type: MU_INPUT_MOUSEUP, X: x, Y: y, Button: btn})
+	ctx.MousePos = NewVec2(x, y)
 	ctx.MouseDown &= ^btn
 }
 
 func (ctx *Context) InputScroll(x, y int) {
+	ctx.record(InputEvent{Type: MU_INPUT_SCROLL, X: x, Y: y})
 	ctx.ScrollDelta.X += x
 	ctx.ScrollDelta.Y += y
 }
 
 func (ctx *Context) InputKeyDown(key int) {
+	ctx.record(InputEvent{Type: MU_INPUT_KEYDOWN, Key: key})
 	ctx.KeyPressed |= key
 	ctx.KeyDown |= key
 }
 
 func (ctx *Context) InputKeyUp(key int) {
+	ctx.record(InputEvent{Type: MU_INPUT_KEYUP, Key: key})
 	ctx.KeyDown &= ^key
 }
 
+// advances the context's clock by dt seconds, should be called once per
+// frame with the time since the last frame. used for tooltip delays and
+// animations
+func (ctx *Context) InputTime(dt float32) {
+	ctx.record(InputEvent{Type: MU_INPUT_TIME, DT: dt})
+	ctx.DeltaTime += dt
+}
+
 func (ctx *Context) InputText(text []rune) {
+	ctx.record(InputEvent{Type: MU_INPUT_TEXT, Text: string(text)})
 	ctx.TextInput = text
 }
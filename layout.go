@@ -4,10 +4,13 @@ package microui
 ** layout
 **============================================================================*/
 
+// value of both coordinates of Layout.Max until the first item is laid out
+const layoutMaxUnset = -0x1000000
+
 func (ctx *Context) PushLayout(body Rect, scroll Vec2) {
 	layout := Layout{}
 	layout.Body = NewRect(body.X-scroll.X, body.Y-scroll.Y, body.W, body.H)
-	layout.Max = NewVec2(-0x1000000, -0x1000000)
+	layout.Max = NewVec2(layoutMaxUnset, layoutMaxUnset)
 
 	// push()
 	ctx.LayoutStack = append(ctx.LayoutStack, layout)
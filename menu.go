@@ -0,0 +1,307 @@
+package microui
+
+/*============================================================================
+** menus
+**============================================================================*/
+
+type menuLevel struct {
+	bar     bool
+	context bool
+	id      mu_Id // id of the menu bar, or of the popup container of a menu
+	rect    Rect
+	x       int // position of the next menu header in a menu bar
+	depth   int // index of the menu in ctx.menuPath, -1 for menu bars and context menus
+	// id and rect of the menu bar header or item that opened the menu
+	openerID   mu_Id
+	openerRect Rect
+}
+
+func (ctx *Context) currentMenu() *menuLevel {
+	expect(len(ctx.menuStack) > 0)
+	return &ctx.menuStack[len(ctx.menuStack)-1]
+}
+
+// closes every open menu, including the context menus being built
+func (ctx *Context) CloseMenus() {
+	ctx.closeMenusFrom(0)
+	ctx.menuBar = 0
+	for _, m := range ctx.menuStack {
+		if m.context {
+			ctx.getContainer(m.id, MU_OPT_CLOSED).Open = false
+		}
+	}
+}
+
+// closes the open menus starting at the given depth
+func (ctx *Context) closeMenusFrom(depth int) {
+	if depth >= len(ctx.menuPath) {
+		return
+	}
+	for _, id := range ctx.menuPath[depth:] {
+		if cnt := ctx.getContainer(id, MU_OPT_CLOSED); cnt != nil {
+			cnt.Open = false
+		}
+	}
+	ctx.menuPath = ctx.menuPath[:depth]
+}
+
+// opens the menu popup with the given name at depth, closing any other menu
+// that was open at that depth
+func (ctx *Context) openMenu(name string, depth int, rect Rect) {
+	ctx.closeMenusFrom(depth)
+	ctx.OpenPopupAt(name, rect)
+	ctx.menuPath = append(ctx.menuPath, ctx.GetID([]byte(name)))
+	ctx.menuOpenFrame = ctx.Frame
+}
+
+// reports whether the menu popup with the given id is open at depth
+func (ctx *Context) menuOpen(id mu_Id, depth int) bool {
+	return depth < len(ctx.menuPath) && ctx.menuPath[depth] == id
+}
+
+// closes all menus if the mouse was pressed outside of them. called from
+// End()
+func (ctx *Context) handleMenuClose() {
+	if len(ctx.menuPath) == 0 || ctx.menuOpenFrame == ctx.Frame {
+		return
+	}
+	if (ctx.KeyPressed & MU_KEY_ESCAPE) != 0 {
+		ctx.CloseMenus()
+		return
+	}
+	if ctx.MousePressed == 0 {
+		return
+	}
+	for _, id := range ctx.menuPath {
+		if cnt := ctx.getContainer(id, MU_OPT_CLOSED); cnt != nil && cnt == ctx.HoverRoot {
+			return
+		}
+	}
+	ctx.CloseMenus()
+}
+
+// begins a horizontal bar of menus, usually placed at the top of a window
+func (ctx *Context) BeginMenuBar() {
+	id := ctx.GetID([]byte("!menubar"))
+	ctx.LayoutRow(1, []int{-1}, 0)
+	r := ctx.LayoutNext()
+	ctx.DrawFrame(ctx, r, MU_COLOR_TITLEBG)
+	// push()
+	ctx.menuStack = append(ctx.menuStack, menuLevel{bar: true, id: id, rect: r, x: r.X, depth: -1})
+}
+
+func (ctx *Context) EndMenuBar() {
+	expect(ctx.currentMenu().bar)
+	// pop()
+	ctx.menuStack = ctx.menuStack[:len(ctx.menuStack)-1]
+}
+
+// returns the width a menu item needs for its label and shortcut
+func (ctx *Context) menuItemWidth(label, shortcut string, h int) int {
+	font := ctx.Style.Font
+	w := h + ctx.TextWidth(font, label) + ctx.Style.Padding*2
+	if len(shortcut) > 0 {
+		w += ctx.Style.Spacing*4 + ctx.TextWidth(font, shortcut)
+	}
+	// leave room for the submenu arrow
+	return w + h
+}
+
+// lays out the next item of a menu popup and grows the popup to fit it
+func (ctx *Context) menuItemRect(label, shortcut string) Rect {
+	ctx.LayoutRow(1, []int{-1}, 0)
+	r := ctx.LayoutNext()
+	layout := ctx.GetLayout()
+	layout.Max.X = mu_max(layout.Max.X, r.X+ctx.menuItemWidth(label, shortcut, r.H))
+	return r
+}
+
+// begins a menu. inside a menu bar this adds a header that opens the menu
+// below it, inside another menu it adds an item that opens a submenu to the
+// right when hovered. returns true if the menu is open, in which case its
+// items should be added and EndMenu called
+func (ctx *Context) BeginMenu(label string) bool {
+	parent := *ctx.currentMenu()
+	id := ctx.GetID([]byte(label))
+	name := label + "!menu"
+	menuID := ctx.GetID([]byte(name))
+	depth := parent.depth + 1
+	font := ctx.Style.Font
+	var r Rect
+
+	if parent.bar {
+		// do menu bar header
+		w := ctx.TextWidth(font, label) + ctx.Style.Padding*2
+		r = NewRect(parent.x, parent.rect.Y, w, parent.rect.H)
+		ctx.currentMenu().x += w
+		ctx.UpdateControl(id, r, 0)
+		open := ctx.menuOpen(menuID, depth)
+		clicked := (ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id) || ctx.KeyActivated(id)
+		popupRect := NewRect(r.X, r.Y+r.H, 1, 1)
+		if clicked && open {
+			ctx.CloseMenus()
+		} else if clicked || (!open && ctx.Hover == id && ctx.menuBar == parent.id && len(ctx.menuPath) > 0) {
+			// open on click, or on hover if another menu of this bar is open
+			ctx.openMenu(name, depth, popupRect)
+			ctx.menuBar = parent.id
+		}
+		open = ctx.menuOpen(menuID, depth)
+
+		// draw
+		if open {
+			ctx.DrawFrame(ctx, r, MU_COLOR_BUTTONFOCUS)
+		} else if ctx.Hover == id {
+			ctx.DrawFrame(ctx, r, MU_COLOR_BUTTONHOVER)
+		}
+		ctx.DrawFocusRing(id, r)
+		ctx.DrawControlText(label, r, MU_COLOR_TEXT, MU_OPT_ALIGNCENTER)
+	} else {
+		// do submenu item
+		r = ctx.menuItemRect(label, "")
+		ctx.UpdateControl(id, r, 0)
+		open := ctx.menuOpen(menuID, depth)
+		if !open && (ctx.Hover == id || ctx.KeyActivated(id)) {
+			ctx.openMenu(name, depth, NewRect(parent.rect.X+parent.rect.W, r.Y-ctx.Style.Padding, 1, 1))
+		}
+		open = ctx.menuOpen(menuID, depth)
+
+		// draw
+		if open || ctx.Hover == id {
+			ctx.DrawFrame(ctx, r, MU_COLOR_BUTTONHOVER)
+		}
+		ctx.DrawFocusRing(id, r)
+		tr := NewRect(r.X+r.H, r.Y, r.W-r.H*2, r.H)
+		ctx.DrawControlText(label, tr, MU_COLOR_TEXT, 0)
+		ctx.DrawIcon(MU_ICON_COLLAPSED, NewRect(r.X+r.W-r.H, r.Y, r.H, r.H), ctx.Style.Colors[MU_COLOR_TEXT])
+	}
+
+	ctx.LastID = id
+	ctx.LastRect = r
+	if !ctx.menuOpen(menuID, depth) {
+		return false
+	}
+
+	// keep the popup attached to the header or item that opened it
+	cnt := ctx.GetContainer(name)
+	if parent.bar {
+		cnt.Rect.X, cnt.Rect.Y = r.X, r.Y+r.H
+	} else {
+		cnt.Rect.X, cnt.Rect.Y = parent.rect.X+parent.rect.W, r.Y-ctx.Style.Padding
+	}
+	opt := MU_OPT_AUTOSIZE | MU_OPT_NORESIZE | MU_OPT_NOSCROLL | MU_OPT_NOTITLE | MU_OPT_CLOSED
+	if ctx.BeginWindowEx(name, cnt.Rect, opt) == 0 {
+		return false
+	}
+	// push()
+	ctx.menuStack = append(ctx.menuStack, menuLevel{id: menuID, rect: cnt.Rect, depth: depth, openerID: id, openerRect: r})
+	return true
+}
+
+func (ctx *Context) EndMenu() {
+	m := *ctx.currentMenu()
+	expect(!m.bar && !m.context)
+	// pop()
+	ctx.menuStack = ctx.menuStack[:len(ctx.menuStack)-1]
+	ctx.EndWindow()
+	// the header or item that opened the menu is the last control again
+	ctx.LastID = m.openerID
+	ctx.LastRect = m.openerRect
+}
+
+// adds an item to the current menu. shortcut is drawn right-aligned next to
+// the label, it is only a hint and doesn't handle any keys. if selected is
+// not nil the item shows a check mark and toggles *selected when activated.
+// returns true if the item was activated, which closes all menus
+func (ctx *Context) MenuItemEx(label, shortcut string, selected *bool, opt int) bool {
+	menu := ctx.currentMenu()
+	id := ctx.GetID([]byte(label))
+	r := ctx.menuItemRect(label, shortcut)
+	ctx.UpdateControl(id, r, opt)
+
+	// hovering an item closes the submenus opened from this menu
+	if ctx.Hover == id && ctx.MouseDelta != (Vec2{}) {
+		ctx.closeMenusFrom(menu.depth + 1)
+	}
+	// handle click
+	activated := (ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id) || ctx.KeyActivated(id)
+	if activated {
+		if selected != nil {
+			*selected = !*selected
+		}
+		ctx.CloseMenus()
+	}
+
+	// draw
+	if ctx.Hover == id {
+		ctx.DrawFrame(ctx, r, MU_COLOR_BUTTONHOVER)
+	}
+	ctx.DrawFocusRing(id, r)
+	if selected != nil && *selected {
+		ctx.DrawIcon(MU_ICON_CHECK, NewRect(r.X, r.Y, r.H, r.H), ctx.Style.Colors[MU_COLOR_TEXT])
+	}
+	tr := NewRect(r.X+r.H, r.Y, r.W-r.H, r.H)
+	ctx.DrawControlText(label, tr, MU_COLOR_TEXT, 0)
+	if len(shortcut) > 0 {
+		ctx.DrawControlText(shortcut, tr, MU_COLOR_TEXT, MU_OPT_ALIGNRIGHT)
+	}
+	return activated
+}
+
+/*============================================================================
+** context menus
+**============================================================================*/
+
+// begins a popup menu that opens at the mouse when the right mouse button is
+// pressed over the last control. if no control was added to the current
+// container yet it opens when the container is right-clicked instead. returns
+// true if the menu is open, in which case its items should be added and
+// EndContextMenu called
+func (ctx *Context) BeginContextMenu(name string) bool {
+	cnt := ctx.GetCurrentContainer()
+	rect := ctx.LastRect
+	if ctx.GetLayout().Max.X == layoutMaxUnset {
+		rect = cnt.Body
+	}
+	id := ctx.GetID([]byte(name))
+	openedBefore := ctx.contextMenuFrame == ctx.Frame
+	over := false
+	if ctx.MousePressed == MU_MOUSE_RIGHT {
+		if openedBefore {
+			// opening the other menu made it the hover root, check against the
+			// hover root it replaced
+			ctx.HoverRoot, ctx.contextMenuRoot = ctx.contextMenuRoot, ctx.HoverRoot
+			over = ctx.MouseOver(rect)
+			ctx.HoverRoot, ctx.contextMenuRoot = ctx.contextMenuRoot, ctx.HoverRoot
+		} else {
+			over = ctx.MouseOver(rect)
+		}
+	}
+	if over {
+		// a control's menu is opened after its container's, let it replace
+		// the container's menu
+		if openedBefore {
+			if ctx.contextMenu != id {
+				ctx.getContainer(ctx.contextMenu, MU_OPT_CLOSED).Open = false
+			}
+		} else {
+			ctx.contextMenuRoot = ctx.HoverRoot
+		}
+		ctx.OpenPopup(name)
+		ctx.contextMenu = id
+		ctx.contextMenuFrame = ctx.Frame
+	}
+	if ctx.BeginPopup(name) == 0 {
+		return false
+	}
+	popup := ctx.GetCurrentContainer()
+	// push()
+	ctx.menuStack = append(ctx.menuStack, menuLevel{context: true, id: id, rect: popup.Rect, depth: -1})
+	return true
+}
+
+func (ctx *Context) EndContextMenu() {
+	expect(ctx.currentMenu().context)
+	// pop()
+	ctx.menuStack = ctx.menuStack[:len(ctx.menuStack)-1]
+	ctx.EndPopup()
+}
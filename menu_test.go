@@ -0,0 +1,193 @@
+package microui
+
+import "testing"
+
+// right-clicks p and reports whether the context menu was open afterwards
+func testContextMenu(ctx *Context, button bool, p Vec2) bool {
+	open := false
+	ui := func() {
+		if button {
+			ctx.LayoutRow(1, []int{-1}, 0)
+			ctx.Button("button")
+		}
+		open = ctx.BeginContextMenu("menu")
+		if open {
+			ctx.MenuItem("item", "", nil)
+			ctx.EndContextMenu()
+		}
+	}
+	ctx.InputMouseMove(p.X, p.Y)
+	testFrame(ctx, ui)
+	testFrame(ctx, ui)
+	ctx.InputMouseDown(p.X, p.Y, MU_MOUSE_RIGHT)
+	testFrame(ctx, ui)
+	ctx.InputMouseUp(p.X, p.Y, MU_MOUSE_RIGHT)
+	testFrame(ctx, ui)
+	return open
+}
+
+func TestContextMenu(t *testing.T) {
+	tests := []struct {
+		name   string
+		button bool
+		p      Vec2
+		open   bool
+	}{
+		// without a control the menu belongs to the window
+		{"empty window", false, Vec2{100, 150}, true},
+		{"button", true, Vec2{20, 35}, true},
+		{"outside button", true, Vec2{100, 150}, false},
+		{"outside window", false, Vec2{300, 300}, false},
+	}
+	for _, tt := range tests {
+		ctx := newTestContext()
+		if open := testContextMenu(ctx, tt.button, tt.p); open != tt.open {
+			t.Errorf("%s: open = %v, want %v", tt.name, open, tt.open)
+		}
+	}
+}
+
+// after EndMenu the header that opened the menu is the last control again,
+// so that e.g. a tooltip or context menu added next belongs to it
+func TestMenuLastRect(t *testing.T) {
+	ctx := newTestContext()
+	var header, after Rect
+	var headerID, afterID mu_Id
+	open := false
+	ui := func() {
+		ctx.BeginMenuBar()
+		open = ctx.BeginMenu("File")
+		if !open {
+			header, headerID = ctx.LastRect, ctx.LastID
+		}
+		if open {
+			ctx.MenuItem("New", "", nil)
+			ctx.MenuItem("Open", "", nil)
+			ctx.EndMenu()
+		}
+		after, afterID = ctx.LastRect, ctx.LastID
+		ctx.EndMenuBar()
+	}
+	testFrame(ctx, ui)
+	testClick(ctx, ui, Vec2{header.X + 5, header.Y + 5})
+	if !open {
+		t.Fatal("menu didn't open")
+	}
+	if after != header || afterID != headerID {
+		t.Errorf("after EndMenu LastRect = %v, LastID = %v, want %v, %v", after, afterID, header, headerID)
+	}
+}
+
+type testMenus struct {
+	rects     map[string]Rect
+	open      map[string]bool
+	activated string
+}
+
+// a menu bar with a File menu that has a Recent submenu
+func testMenuUI(ctx *Context, m *testMenus) func() {
+	m.rects = map[string]Rect{}
+	m.open = map[string]bool{}
+	item := func(label string) {
+		if ctx.MenuItem(label, "", nil) {
+			m.activated = label
+		}
+		m.rects[label] = ctx.LastRect
+	}
+	return func() {
+		m.open = map[string]bool{}
+		ctx.BeginMenuBar()
+		open := ctx.BeginMenu("File")
+		m.open["File"] = open
+		if open {
+			item("New")
+			sub := ctx.BeginMenu("Recent")
+			m.open["Recent"] = sub
+			if sub {
+				item("a.txt")
+				item("b.txt")
+				ctx.EndMenu()
+			}
+			m.rects["Recent"] = ctx.LastRect
+			item("Quit")
+			ctx.EndMenu()
+		}
+		m.rects["File"] = ctx.LastRect
+		ctx.EndMenuBar()
+	}
+}
+
+func testCenter(r Rect) Vec2 {
+	return Vec2{r.X + r.W/2, r.Y + r.H/2}
+}
+
+// moves the mouse to p and keeps it there for a few frames, so that a popup
+// opened by hovering stops taking the hover
+func testHover(ctx *Context, ui func(), p Vec2) {
+	ctx.InputMouseMove(p.X, p.Y)
+	for i := 0; i < 3; i++ {
+		testFrame(ctx, ui)
+	}
+}
+
+func TestMenuNested(t *testing.T) {
+	ctx := newTestContext()
+	m := &testMenus{}
+	ui := testMenuUI(ctx, m)
+	testFrame(ctx, ui)
+	testClick(ctx, ui, testCenter(m.rects["File"]))
+	if !m.open["File"] || m.open["Recent"] {
+		t.Fatalf("clicking File gave open = %v", m.open)
+	}
+
+	// hovering the submenu item opens the submenu
+	testHover(ctx, ui, testCenter(m.rects["Recent"]))
+	if !m.open["Recent"] {
+		t.Fatalf("hovering Recent didn't open it")
+	}
+
+	// hovering another item of the parent menu closes it again
+	testHover(ctx, ui, testCenter(m.rects["Quit"]))
+	if !m.open["File"] || m.open["Recent"] {
+		t.Fatalf("hovering Quit gave open = %v", m.open)
+	}
+
+	// clicking an item of the submenu activates it and closes every menu
+	testHover(ctx, ui, testCenter(m.rects["Recent"]))
+	testClick(ctx, ui, testCenter(m.rects["b.txt"]))
+	if m.activated != "b.txt" || m.open["File"] || m.open["Recent"] {
+		t.Errorf("clicking b.txt gave activated = %q, open = %v", m.activated, m.open)
+	}
+}
+
+func TestMenuAutoClose(t *testing.T) {
+	tests := []struct {
+		name  string
+		close func(ctx *Context, ui func(), m *testMenus)
+	}{
+		{"click outside", func(ctx *Context, ui func(), m *testMenus) {
+			testClick(ctx, ui, Vec2{150, 180})
+		}},
+		{"escape", func(ctx *Context, ui func(), m *testMenus) {
+			testKey(ctx, ui, MU_KEY_ESCAPE)
+		}},
+		{"click header", func(ctx *Context, ui func(), m *testMenus) {
+			testClick(ctx, ui, testCenter(m.rects["File"]))
+		}},
+	}
+	for _, tt := range tests {
+		ctx := newTestContext()
+		m := &testMenus{}
+		ui := testMenuUI(ctx, m)
+		testFrame(ctx, ui)
+		testClick(ctx, ui, testCenter(m.rects["File"]))
+		testHover(ctx, ui, testCenter(m.rects["Recent"]))
+		if !m.open["File"] || !m.open["Recent"] {
+			t.Fatalf("%s: menus didn't open, open = %v", tt.name, m.open)
+		}
+		tt.close(ctx, ui, m)
+		if m.open["File"] || m.open["Recent"] || m.activated != "" {
+			t.Errorf("%s: open = %v, activated = %q after closing", tt.name, m.open, m.activated)
+		}
+	}
+}
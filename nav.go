@@ -0,0 +1,184 @@
+package microui
+
+/*============================================================================
+** keyboard navigation
+**============================================================================*/
+
+// returns the root container of the container that is currently being built
+func (ctx *Context) getRootContainer() *Container {
+	for i := len(ctx.ContainerStack) - 1; i >= 0; i-- {
+		// only root containers have their `head` field set
+		if ctx.ContainerStack[i].HeadIdx >= 0 {
+			return ctx.ContainerStack[i]
+		}
+	}
+	return nil
+}
+
+// adds a control to this frame's list of keyboard focusable controls
+func (ctx *Context) addNavItem(id mu_Id, rect Rect, opt int) {
+	if (opt & MU_OPT_NONAV) != 0 {
+		return
+	}
+	ctx.navList = append(ctx.navList, navItem{id, rect, ctx.getRootContainer()})
+}
+
+// gives keyboard focus to the control with the given id
+func (ctx *Context) SetKeyboardFocus(id mu_Id) {
+	ctx.SetFocus(id)
+	ctx.KeyboardFocus = id != 0
+}
+
+// reports whether the control with the given id has keyboard focus and was
+// activated with return or space this frame
+func (ctx *Context) KeyActivated(id mu_Id) bool {
+	return ctx.KeyboardFocus && ctx.Focus == id &&
+		(ctx.KeyPressed&(MU_KEY_RETURN|MU_KEY_SPACE)) != 0
+}
+
+// draws a focus ring around rect if the control has keyboard focus
+func (ctx *Context) DrawFocusRing(id mu_Id, rect Rect) {
+	if ctx.KeyboardFocus && ctx.Focus == id {
+		ctx.DrawBox(expand_rect(rect, 2), ctx.Style.Colors[MU_COLOR_FOCUSRING])
+	}
+}
+
+// returns the index of the focused control in the nav list, or -1
+func (ctx *Context) navFocusIndex() int {
+	if ctx.Focus == 0 {
+		return -1
+	}
+	for i := range ctx.navList {
+		if ctx.navList[i].ID == ctx.Focus {
+			return i
+		}
+	}
+	return -1
+}
+
+// returns the root container keyboard navigation happens in: the root of the
+// focused control, or the frontmost root if nothing is focused
+func (ctx *Context) navRoot(focus int) *Container {
+	if focus >= 0 {
+		return ctx.navList[focus].Root
+	}
+	var root *Container
+	for _, item := range ctx.navList {
+		if root == nil || (item.Root != nil && item.Root.Zindex > root.Zindex) {
+			root = item.Root
+		}
+	}
+	return root
+}
+
+// moves focus to the next (or previous if shift is held) control of the
+// current root container when tab is pressed. called from End()
+func (ctx *Context) handleTabNavigation() {
+	if (ctx.KeyPressed&MU_KEY_TAB) == 0 || len(ctx.navList) == 0 {
+		return
+	}
+	dir := 1
+	if (ctx.KeyDown & MU_KEY_SHIFT) != 0 {
+		dir = -1
+	}
+	focus := ctx.navFocusIndex()
+	root := ctx.navRoot(focus)
+	i := focus
+	if i < 0 && dir < 0 {
+		i = 0
+	}
+	n := len(ctx.navList)
+	for step := 0; step < n; step++ {
+		i = ((i+dir)%n + n) % n
+		if ctx.navList[i].Root == root {
+			ctx.SetKeyboardFocus(ctx.navList[i].ID)
+			return
+		}
+	}
+}
+
+// moves focus to the nearest control of the current root container in the
+// direction of the pressed arrow key. called from End()
+func (ctx *Context) handleDirectionalNavigation() {
+	keys := ctx.KeyPressed & (MU_KEY_LEFT | MU_KEY_RIGHT | MU_KEY_UP | MU_KEY_DOWN)
+	if !ctx.DirectionalNav || keys == 0 || len(ctx.navList) == 0 {
+		return
+	}
+	// text controls use the arrow keys to move the caret
+	if ctx.Focus != 0 && ctx.Focus == ctx.TextEdit {
+		return
+	}
+	focus := ctx.navFocusIndex()
+	root := ctx.navRoot(focus)
+	if focus < 0 {
+		// nothing is focused yet, start at the first control
+		for i := range ctx.navList {
+			if ctx.navList[i].Root == root {
+				ctx.SetKeyboardFocus(ctx.navList[i].ID)
+				return
+			}
+		}
+		return
+	}
+
+	var dir Vec2
+	switch {
+	case (keys & MU_KEY_LEFT) != 0:
+		dir.X = -1
+	case (keys & MU_KEY_RIGHT) != 0:
+		dir.X = 1
+	case (keys & MU_KEY_UP) != 0:
+		dir.Y = -1
+	default:
+		dir.Y = 1
+	}
+
+	from := ctx.navList[focus].Rect
+	best, bestScore := -1, 0
+	for i, item := range ctx.navList {
+		if i == focus || item.Root != root || item.ID == ctx.Focus {
+			continue
+		}
+		score, ok := navScore(from, item.Rect, dir)
+		if ok && (best < 0 || score < bestScore) {
+			best, bestScore = i, score
+		}
+	}
+	if best >= 0 {
+		ctx.SetKeyboardFocus(ctx.navList[best].ID)
+	}
+}
+
+// returns how far rect `to` is from rect `from` in direction dir, lower is
+// closer. ok is false if `to` is not in that direction at all
+func navScore(from, to Rect, dir Vec2) (score int, ok bool) {
+	// distance along the direction between the facing edges, and the
+	// distance between the centers perpendicular to it
+	var along, across int
+	if dir.X != 0 {
+		if dir.X > 0 {
+			along = to.X - (from.X + from.W)
+		} else {
+			along = from.X - (to.X + to.W)
+		}
+		across = (to.Y + to.H/2) - (from.Y + from.H/2)
+	} else {
+		if dir.Y > 0 {
+			along = to.Y - (from.Y + from.H)
+		} else {
+			along = from.Y - (to.Y + to.H)
+		}
+		across = (to.X + to.W/2) - (from.X + from.W/2)
+	}
+	if across < 0 {
+		across = -across
+	}
+	// allow slight overlap, rects laid out next to each other touch or are
+	// separated by the spacing
+	if along < -mu_min(from.W, from.H)/2 {
+		return 0, false
+	}
+	along = mu_max(along, 0)
+	// prefer controls that are in line with the current one
+	return along + across*2, true
+}
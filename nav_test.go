@@ -0,0 +1,100 @@
+package microui
+
+import "testing"
+
+func TestNavScore(t *testing.T) {
+	from := NewRect(100, 100, 50, 20)
+	tests := []struct {
+		name  string
+		to    Rect
+		dir   Vec2
+		score int
+		ok    bool
+	}{
+		{"right, in line", NewRect(154, 100, 50, 20), Vec2{1, 0}, 4, true},
+		{"right, touching", NewRect(150, 100, 50, 20), Vec2{1, 0}, 0, true},
+		{"right, offset down", NewRect(160, 130, 50, 20), Vec2{1, 0}, 10 + 30*2, true},
+		{"right, but to the left", NewRect(40, 100, 50, 20), Vec2{1, 0}, 0, false},
+		{"left", NewRect(40, 100, 50, 20), Vec2{-1, 0}, 10, true},
+		{"down", NewRect(100, 124, 50, 20), Vec2{0, 1}, 4, true},
+		{"down, offset right", NewRect(130, 124, 50, 20), Vec2{0, 1}, 4 + 30*2, true},
+		{"up", NewRect(100, 76, 50, 20), Vec2{0, -1}, 4, true},
+		{"up, but below", NewRect(100, 124, 50, 20), Vec2{0, -1}, 0, false},
+		// slight overlap still counts as being in that direction
+		{"down, overlapping", NewRect(100, 115, 50, 20), Vec2{0, 1}, 0, true},
+		{"down, same row", NewRect(100, 100, 50, 20), Vec2{0, 1}, 0, false},
+	}
+	for _, tt := range tests {
+		score, ok := navScore(from, tt.to, tt.dir)
+		if ok != tt.ok || (ok && score != tt.score) {
+			t.Errorf("%s: navScore = %d, %v, want %d, %v", tt.name, score, ok, tt.score, tt.ok)
+		}
+	}
+}
+
+// moves through a 2x2 grid of buttons with the arrow keys
+func TestDirectionalNavigation(t *testing.T) {
+	ctx := newTestContext()
+	ctx.DirectionalNav = true
+	var ids [4]mu_Id
+	ui := func() {
+		ctx.LayoutRow(2, []int{80, 80}, 0)
+		for i, label := range []string{"a", "b", "c", "d"} {
+			ctx.Button(label)
+			ids[i] = ctx.LastID
+		}
+	}
+	testFrame(ctx, ui)
+	steps := []struct {
+		key  int
+		want int
+	}{
+		{MU_KEY_DOWN, 0}, // nothing focused, start at the first control
+		{MU_KEY_RIGHT, 1},
+		{MU_KEY_DOWN, 3},
+		{MU_KEY_DOWN, 3}, // nothing below, focus stays
+		{MU_KEY_LEFT, 2},
+		{MU_KEY_UP, 0},
+	}
+	for i, s := range steps {
+		testKey(ctx, ui, s.key)
+		if ctx.Focus != ids[s.want] {
+			t.Fatalf("step %d: focus is not on button %d", i, s.want)
+		}
+	}
+}
+
+// counts the rect commands drawn in the focus ring color
+func testFocusRingRects(ctx *Context) int {
+	n := 0
+	ctx.Render(func(cmd *Command) {
+		if cmd.Type == MU_COMMAND_RECT && cmd.Rect.Color == ctx.Style.Colors[MU_COLOR_FOCUSRING] {
+			n++
+		}
+	})
+	return n
+}
+
+// tabs to each control and checks that exactly one ring, made of 4 rects, is
+// drawn for it, also for MU_OPT_NOFRAME controls and a custom DrawFrame
+func TestFocusRingDrawnOnce(t *testing.T) {
+	ctx := newTestContext()
+	// a custom frame that fills every rect it is given
+	ctx.DrawFrame = func(ctx *Context, rect Rect, colorid int) {
+		ctx.DrawRect(rect, ctx.Style.Colors[colorid])
+	}
+	v := float32(0.5)
+	ui := func() {
+		ctx.LayoutRow(1, []int{-1}, 0)
+		ctx.Slider(&v, 0, 1)
+		ctx.ButtonEx("noframe", 0, MU_OPT_NOFRAME)
+		ctx.Button("button")
+	}
+	testFrame(ctx, ui)
+	for i := 0; i < 3; i++ {
+		testKey(ctx, ui, MU_KEY_TAB)
+		if n := testFocusRingRects(ctx); n != 4 {
+			t.Errorf("control %d: %d focus ring rects, want 4", i, n)
+		}
+	}
+}
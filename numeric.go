@@ -0,0 +1,204 @@
+package microui
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"unsafe"
+)
+
+/*============================================================================
+** generic number controls
+**============================================================================*/
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type Float interface {
+	~float32 | ~float64
+}
+
+// value types accepted by SliderT and NumberT
+type Numeric interface {
+	Integer | Float
+}
+
+func isInteger[T Numeric]() bool {
+	var one T = 1
+	return one/2 == 0
+}
+
+func isUnsigned[T Numeric]() bool {
+	var zero T
+	return zero-1 > 0
+}
+
+// returns the smallest and largest value of the integer type T
+func integerRange[T Numeric]() (T, T) {
+	var zero T
+	if isUnsigned[T]() {
+		return 0, zero - 1
+	}
+	bits := unsafe.Sizeof(zero) * 8
+	max := T(uint64(1)<<(bits-1) - 1)
+	return -max - 1, max
+}
+
+// converts f to T, rounding to the nearest integer and clamping to the range
+// of T for integer types
+func fromFloat[T Numeric](f float64) T {
+	if isInteger[T]() {
+		f = math.Round(f)
+		// converting out of range values doesn't wrap in a defined way
+		lo, hi := integerRange[T]()
+		if f <= float64(lo) {
+			return lo
+		} else if f >= float64(hi) {
+			return hi
+		}
+	}
+	return T(f)
+}
+
+func numberFormat[T Numeric]() string {
+	if isInteger[T]() {
+		return "%d"
+	}
+	return MU_REAL_FMT
+}
+
+// parses a number typed into a number control, invalid input gives 0
+func parseNumber[T Numeric](s string) T {
+	if isInteger[T]() {
+		// parse integers directly so large values don't lose precision. out
+		// of range values give ErrRange along with the closest value of T
+		var zero T
+		bits := int(unsafe.Sizeof(zero)) * 8
+		if isUnsigned[T]() {
+			if n, err := strconv.ParseUint(s, 10, bits); err == nil || errors.Is(err, strconv.ErrRange) {
+				return T(n)
+			}
+		} else if n, err := strconv.ParseInt(s, 10, bits); err == nil || errors.Is(err, strconv.ErrRange) {
+			return T(n)
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return fromFloat[T](f)
+}
+
+// shift+clicking a number control turns it into a textbox until it loses
+// focus or return is pressed. returns true while the textbox is shown
+func numberTextBox[T Numeric](ctx *Context, value *T, r Rect, id mu_Id) bool {
+	if ctx.MousePressed == MU_MOUSE_LEFT && (ctx.KeyDown&MU_KEY_SHIFT) != 0 &&
+		ctx.Hover == id {
+		ctx.NumberEdit = id
+		ctx.NumberEditBuf = fmt.Sprintf(numberFormat[T](), *value)
+	}
+	if ctx.NumberEdit == id {
+		res := ctx.TextboxRaw(&ctx.NumberEditBuf, id, r, 0)
+		if (res&MU_RES_SUBMIT) != 0 || ctx.Focus != id {
+			*value = parseNumber[T](ctx.NumberEditBuf)
+			ctx.NumberEdit = 0
+		} else {
+			return true
+		}
+	}
+	return false
+}
+
+// a slider for any integer or float type. values are snapped to multiples of
+// step if it isn't 0, integer values are always whole. format is used with
+// fmt.Sprintf, so integers need a verb like %d. pass MU_OPT_VERTICAL for a
+// slider that goes from low at the bottom to high at the top
+func SliderT[T Numeric](ctx *Context, value *T, low, high, step T, format string, opt int) int {
+	var res int = 0
+	last := *value
+	v := last
+	id := ctx.GetID(unsafe.Slice((*byte)(unsafe.Pointer(&value)), unsafe.Sizeof(value)))
+	base := ctx.LayoutNext()
+
+	// handle text input mode
+	if numberTextBox(ctx, &v, base, id) {
+		undoTrackValue(ctx, id, value, last)
+		return res
+	}
+
+	// handle normal mode
+	ctx.UpdateControl(id, base, opt)
+
+	// handle input, working in float64 so integer ranges can't overflow
+	flow, fhigh := float64(low), float64(high)
+	f := float64(v)
+	if ctx.Focus == id && (ctx.MouseDown|ctx.MousePressed) == MU_MOUSE_LEFT {
+		f = ctx.sliderValueAt(base, flow, fhigh, float64(step), opt)
+	}
+	// clamp and store value, update res
+	*value = fromFloat[T](math.Min(fhigh, math.Max(flow, f)))
+	v = *value
+	if last != v {
+		res |= MU_RES_CHANGE
+	}
+	undoTrackValue(ctx, id, value, last)
+
+	// draw base
+	ctx.DrawControlFrame(id, base, MU_COLOR_BASE, opt)
+	ctx.DrawFocusRing(id, base)
+	// draw thumb
+	thumb := ctx.sliderThumb(base, float64(v), flow, fhigh, opt)
+	ctx.DrawControlFrame(id, thumb, MU_COLOR_BUTTON, opt)
+	// draw text
+	text := fmt.Sprintf(format, v)
+	ctx.DrawControlText(text, base, MU_COLOR_TEXT, opt)
+
+	return res
+}
+
+// a number control for any integer or float type, dragging it changes the
+// value by step per pixel
+func NumberT[T Numeric](ctx *Context, value *T, step T, format string, opt int) int {
+	id := ctx.GetID(unsafe.Slice((*byte)(unsafe.Pointer(&value)), unsafe.Sizeof(value)))
+	base := ctx.LayoutNext()
+	last := *value
+	res := numberRect(ctx, id, value, step, format, base, opt)
+	undoTrackValue(ctx, id, value, last)
+	return res
+}
+
+// does a number control with the given id in rect base, without recording
+// undo entries
+func numberRect[T Numeric](ctx *Context, id mu_Id, value *T, step T, format string, base Rect, opt int) int {
+	var res int = 0
+	last := *value
+
+	// handle text input mode
+	if numberTextBox(ctx, value, base, id) {
+		return res
+	}
+
+	// handle normal mode
+	ctx.UpdateControl(id, base, opt)
+
+	// handle input
+	if ctx.Focus == id && ctx.MouseDown == MU_MOUSE_LEFT && ctx.MouseDelta.X != 0 {
+		*value = fromFloat[T](float64(*value) + float64(ctx.MouseDelta.X)*float64(step))
+	}
+	// set flag if value changed
+	if *value != last {
+		res |= MU_RES_CHANGE
+	}
+
+	// draw base
+	ctx.DrawControlFrame(id, base, MU_COLOR_BASE, opt)
+	ctx.DrawFocusRing(id, base)
+	// draw text
+	text := fmt.Sprintf(format, *value)
+	ctx.DrawControlText(text, base, MU_COLOR_TEXT, opt)
+
+	return res
+}
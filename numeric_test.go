@@ -0,0 +1,82 @@
+package microui
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseNumber(t *testing.T) {
+	if got := parseNumber[int8]("300"); got != 127 {
+		t.Errorf("int8 300 = %v, want 127", got)
+	}
+	if got := parseNumber[int8]("-300"); got != -128 {
+		t.Errorf("int8 -300 = %v, want -128", got)
+	}
+	if got := parseNumber[int8]("12.6"); got != 13 {
+		t.Errorf("int8 12.6 = %v, want 13", got)
+	}
+	if got := parseNumber[uint8]("-5"); got != 0 {
+		t.Errorf("uint8 -5 = %v, want 0", got)
+	}
+	if got := parseNumber[uint8]("1e9"); got != 255 {
+		t.Errorf("uint8 1e9 = %v, want 255", got)
+	}
+	if got := parseNumber[int64]("9223372036854775807"); got != math.MaxInt64 {
+		t.Errorf("int64 max = %v, want %v", got, int64(math.MaxInt64))
+	}
+	if got := parseNumber[uint64]("99999999999999999999"); got != math.MaxUint64 {
+		t.Errorf("uint64 overflow = %v, want %v", got, uint64(math.MaxUint64))
+	}
+	if got := parseNumber[float32]("2.5"); got != 2.5 {
+		t.Errorf("float32 2.5 = %v, want 2.5", got)
+	}
+	if got := parseNumber[int]("abc"); got != 0 {
+		t.Errorf("int abc = %v, want 0", got)
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	if got := fromFloat[int8](1000); got != 127 {
+		t.Errorf("int8 1000 = %v, want 127", got)
+	}
+	if got := fromFloat[int16](-1e9); got != -32768 {
+		t.Errorf("int16 -1e9 = %v, want -32768", got)
+	}
+	if got := fromFloat[uint16](-3); got != 0 {
+		t.Errorf("uint16 -3 = %v, want 0", got)
+	}
+	if got := fromFloat[int64](1e30); got != math.MaxInt64 {
+		t.Errorf("int64 1e30 = %v, want %v", got, int64(math.MaxInt64))
+	}
+	if got := fromFloat[uint64](1e30); got != math.MaxUint64 {
+		t.Errorf("uint64 1e30 = %v, want %v", got, uint64(math.MaxUint64))
+	}
+	if got := fromFloat[int](2.5); got != 3 {
+		t.Errorf("int 2.5 = %v, want 3", got)
+	}
+}
+
+func TestNumberTDragClamps(t *testing.T) {
+	ctx := newTestContext()
+	v := int8(120)
+	var r Rect
+	ui := func() {
+		ctx.LayoutRow(1, []int{-1}, 0)
+		NumberT(ctx, &v, 1, "%d", 0)
+		r = ctx.LastRect
+	}
+	testFrame(ctx, ui)
+	p := Vec2{r.X + 5, r.Y + 5}
+	ctx.InputMouseMove(p.X, p.Y)
+	testFrame(ctx, ui)
+	testFrame(ctx, ui)
+	ctx.InputMouseDown(p.X, p.Y, MU_MOUSE_LEFT)
+	testFrame(ctx, ui)
+	ctx.InputMouseMove(p.X+50, p.Y)
+	testFrame(ctx, ui)
+	ctx.InputMouseUp(p.X+50, p.Y, MU_MOUSE_LEFT)
+	testFrame(ctx, ui)
+	if v != 127 {
+		t.Errorf("dragging past the maximum gave %v, want 127", v)
+	}
+}
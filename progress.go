@@ -0,0 +1,57 @@
+package microui
+
+import (
+	"fmt"
+	"math"
+)
+
+/*============================================================================
+** progress bar and spinner
+**============================================================================*/
+
+// draws a bar filled up to fraction (0 to 1). overlay is drawn on top of the
+// bar, if it is empty the percentage is shown instead
+func (ctx *Context) ProgressBar(fraction float32, overlay string, opt int) {
+	r := ctx.LayoutNext()
+	fraction = mu_clamp_real(fraction, 0, 1)
+	ctx.DrawFrame(ctx, r, MU_COLOR_BASE)
+	if w := int(float32(r.W) * fraction); w > 0 {
+		ctx.DrawRect(NewRect(r.X, r.Y, w, r.H), ctx.Style.Colors[MU_COLOR_BUTTON])
+	}
+	if len(overlay) == 0 {
+		overlay = fmt.Sprintf("%d%%", int(fraction*100))
+	}
+	ctx.DrawControlText(overlay, r, MU_COLOR_TEXT, opt)
+}
+
+const spinnerDots = 8
+
+// draws a ring of dots with one of them highlighted, going around once per
+// second as ctx.Time advances. used to show that work of unknown length is in
+// progress
+func (ctx *Context) Spinner(opt int) {
+	r := ctx.LayoutNext()
+	size := mu_min(r.W, r.H)
+	x := r.X
+	if (opt & MU_OPT_ALIGNCENTER) != 0 {
+		x = r.X + (r.W-size)/2
+	} else if (opt & MU_OPT_ALIGNRIGHT) != 0 {
+		x = r.X + r.W - size
+	}
+	y := r.Y + (r.H-size)/2
+	dot := mu_max(size/5, 2)
+	radius := float64(size-dot) / 2
+	cx, cy := float64(x)+float64(size)/2, float64(y)+float64(size)/2
+
+	head := int(ctx.Time*spinnerDots) % spinnerDots
+	for i := 0; i < spinnerDots; i++ {
+		a := 2 * math.Pi * float64(i) / spinnerDots
+		px := int(math.Round(cx + math.Sin(a)*radius - float64(dot)/2))
+		py := int(math.Round(cy - math.Cos(a)*radius - float64(dot)/2))
+		// dots fade out the further they are behind the highlighted one
+		age := (head - i + spinnerDots) % spinnerDots
+		color := ctx.Style.Colors[MU_COLOR_TEXT]
+		color.A = uint8(int(color.A) * (spinnerDots - age) / spinnerDots)
+		ctx.DrawRect(NewRect(px, py, dot, dot), color)
+	}
+}
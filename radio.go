@@ -0,0 +1,51 @@
+package microui
+
+import "unsafe"
+
+/*============================================================================
+** radio buttons
+**============================================================================*/
+
+// a radio button that is drawn as selected if active is true. returns true if
+// it was clicked
+func (ctx *Context) RadioEx(label string, active bool, opt int) bool {
+	id := ctx.GetID([]byte(label))
+	r := ctx.LayoutNext()
+	box := NewRect(r.X, r.Y, r.H, r.H)
+	ctx.UpdateControl(id, r, opt)
+	// handle click
+	clicked := (ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id) || ctx.KeyActivated(id)
+	// draw
+	colorid := MU_COLOR_BASE
+	if ctx.Focus == id {
+		colorid = MU_COLOR_BASEFOCUS
+	} else if ctx.Hover == id {
+		colorid = MU_COLOR_BASEHOVER
+	}
+	ctx.DrawIcon(MU_ICON_RADIO, box, ctx.Style.Colors[colorid])
+	ctx.DrawFocusRing(id, box)
+	if active {
+		ctx.DrawIcon(MU_ICON_RADIOCHECK, box, ctx.Style.Colors[MU_COLOR_TEXT])
+	}
+	r = NewRect(r.X+box.W, r.Y, r.W-box.W, r.H)
+	ctx.DrawControlText(label, r, MU_COLOR_TEXT, 0)
+	return clicked
+}
+
+// a group of radio buttons, one for each of options, laid out with the
+// current layout. *value is the index of the selected option. returns
+// MU_RES_CHANGE if the selection changed
+func (ctx *Context) RadioGroup(value *int, options []string, opt int) int {
+	var res int = 0
+	ctx.PushID(unsafe.Slice((*byte)(unsafe.Pointer(&value)), unsafe.Sizeof(value)))
+	for i, label := range options {
+		if ctx.RadioEx(label, *value == i, opt) && *value != i {
+			last, v := *value, i
+			*value = v
+			ctx.PushUndo(func() { *value = last }, func() { *value = v })
+			res |= MU_RES_CHANGE
+		}
+	}
+	ctx.PopID()
+	return res
+}
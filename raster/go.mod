@@ -0,0 +1,10 @@
+module github.com/zeozeozeo/microui-go/raster
+
+go 1.19
+
+require (
+	github.com/zeozeozeo/microui-go v0.0.0
+	golang.org/x/image v0.18.0
+)
+
+replace github.com/zeozeozeo/microui-go => ../
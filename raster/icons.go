@@ -0,0 +1,117 @@
+package raster
+
+import (
+	"image/color"
+	"math"
+
+	microui "github.com/zeozeozeo/microui-go"
+)
+
+/*============================================================================
+** icons
+**============================================================================*/
+
+// draws one of the built-in MU_ICON_* icons centered inside rect. unknown
+// icon ids are ignored
+func (r *Renderer) DrawIcon(id int, rect microui.Rect, c microui.Color) {
+	col := toNRGBA(c)
+	// icons are drawn inside a centered square that is half the size of the
+	// smaller side of rect
+	s := float64(minInt(rect.W, rect.H)) / 2
+	cx := float64(rect.X) + float64(rect.W)/2
+	cy := float64(rect.Y) + float64(rect.H)/2
+	h := s / 2
+	t := math.Max(1, s/6) // stroke thickness
+
+	switch id {
+	case microui.MU_ICON_CLOSE:
+		r.strokeLine(cx-h, cy-h, cx+h, cy+h, t, col)
+		r.strokeLine(cx-h, cy+h, cx+h, cy-h, t, col)
+	case microui.MU_ICON_CHECK:
+		r.strokeLine(cx-h, cy, cx-h/3, cy+h*2/3, t, col)
+		r.strokeLine(cx-h/3, cy+h*2/3, cx+h, cy-h*2/3, t, col)
+	case microui.MU_ICON_COLLAPSED:
+		r.fillTriangle(cx-h/2, cy-h, cx+h/2, cy, cx-h/2, cy+h, col)
+	case microui.MU_ICON_EXPANDED:
+		r.fillTriangle(cx-h, cy-h/2, cx+h, cy-h/2, cx, cy+h/2, col)
+	case microui.MU_ICON_RADIO:
+		// the radio button's base fills most of the rect, like a checkbox's
+		// frame does
+		r.fillCircle(cx, cy, float64(minInt(rect.W, rect.H))/2-1, col)
+	case microui.MU_ICON_RADIOCHECK:
+		r.fillCircle(cx, cy, h*0.8, col)
+	}
+}
+
+// draws a line from (x0, y0) to (x1, y1) with thickness t
+func (r *Renderer) strokeLine(x0, y0, x1, y1, t float64, c color.NRGBA) {
+	ht := t / 2
+	dx, dy := x1-x0, y1-y0
+	ll := dx*dx + dy*dy
+	minx := int(math.Floor(math.Min(x0, x1) - ht))
+	maxx := int(math.Ceil(math.Max(x0, x1) + ht))
+	miny := int(math.Floor(math.Min(y0, y1) - ht))
+	maxy := int(math.Ceil(math.Max(y0, y1) + ht))
+	for y := miny; y <= maxy; y++ {
+		for x := minx; x <= maxx; x++ {
+			// distance from the pixel center to the segment
+			px, py := float64(x)+0.5, float64(y)+0.5
+			u := 0.0
+			if ll > 0 {
+				u = math.Max(0, math.Min(1, ((px-x0)*dx+(py-y0)*dy)/ll))
+			}
+			ex, ey := px-(x0+u*dx), py-(y0+u*dy)
+			if ex*ex+ey*ey <= ht*ht {
+				r.blend(x, y, c)
+			}
+		}
+	}
+}
+
+// fills the triangle (x0, y0), (x1, y1), (x2, y2)
+func (r *Renderer) fillTriangle(x0, y0, x1, y1, x2, y2 float64, c color.NRGBA) {
+	edge := func(ax, ay, bx, by, px, py float64) float64 {
+		return (bx-ax)*(py-ay) - (by-ay)*(px-ax)
+	}
+	area := edge(x0, y0, x1, y1, x2, y2)
+	if area == 0 {
+		return
+	}
+	minx := int(math.Floor(math.Min(x0, math.Min(x1, x2))))
+	maxx := int(math.Ceil(math.Max(x0, math.Max(x1, x2))))
+	miny := int(math.Floor(math.Min(y0, math.Min(y1, y2))))
+	maxy := int(math.Ceil(math.Max(y0, math.Max(y1, y2))))
+	for y := miny; y <= maxy; y++ {
+		for x := minx; x <= maxx; x++ {
+			px, py := float64(x)+0.5, float64(y)+0.5
+			// the pixel is inside if it is on the same side of every edge
+			w0 := edge(x1, y1, x2, y2, px, py) * area
+			w1 := edge(x2, y2, x0, y0, px, py) * area
+			w2 := edge(x0, y0, x1, y1, px, py) * area
+			if w0 >= 0 && w1 >= 0 && w2 >= 0 {
+				r.blend(x, y, c)
+			}
+		}
+	}
+}
+
+// fills the circle with center (cx, cy) and radius rad
+func (r *Renderer) fillCircle(cx, cy, rad float64, c color.NRGBA) {
+	minx, maxx := int(math.Floor(cx-rad)), int(math.Ceil(cx+rad))
+	miny, maxy := int(math.Floor(cy-rad)), int(math.Ceil(cy+rad))
+	for y := miny; y <= maxy; y++ {
+		for x := minx; x <= maxx; x++ {
+			dx, dy := float64(x)+0.5-cx, float64(y)+0.5-cy
+			if dx*dx+dy*dy <= rad*rad {
+				r.blend(x, y, c)
+			}
+		}
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,143 @@
+// Package raster is a pure-Go software renderer for microui. It consumes the
+// command list produced by a microui.Context and paints it into an
+// *image.RGBA, which makes it possible to render UIs without a GPU, e.g. for
+// CI screenshots or server-side rendering.
+package raster
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	microui "github.com/zeozeozeo/microui-go"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// DefaultFace is used for text commands whose font is not a font.Face
+var DefaultFace font.Face = basicfont.Face7x13
+
+type Renderer struct {
+	Image *image.RGBA
+	// Face is used for text commands whose font is not a font.Face; if it is
+	// nil, DefaultFace is used
+	Face font.Face
+
+	clip image.Rectangle
+}
+
+// creates a new renderer that draws into a new w*h image
+func NewRenderer(w, h int) *Renderer {
+	r := &Renderer{Image: image.NewRGBA(image.Rect(0, 0, w, h))}
+	r.ResetClip()
+	return r
+}
+
+// resets the clip rect to the whole image. Render calls this before drawing
+// the command list, it only needs to be called when using Draw directly
+func (r *Renderer) ResetClip() {
+	r.clip = r.Image.Bounds()
+}
+
+// sets the TextWidth and TextHeight callbacks of ctx so that text is measured
+// with the same faces the renderer draws with
+func (r *Renderer) Attach(ctx *microui.Context) {
+	ctx.TextWidth = r.TextWidth
+	ctx.TextHeight = r.TextHeight
+}
+
+func (r *Renderer) face(f microui.Font) font.Face {
+	if face, ok := f.(font.Face); ok && face != nil {
+		return face
+	}
+	if r.Face != nil {
+		return r.Face
+	}
+	return DefaultFace
+}
+
+func (r *Renderer) TextWidth(f microui.Font, str string) int {
+	return font.MeasureString(r.face(f), str).Ceil()
+}
+
+func (r *Renderer) TextHeight(f microui.Font) int {
+	return r.face(f).Metrics().Height.Ceil()
+}
+
+// fills the whole image with c
+func (r *Renderer) Clear(c microui.Color) {
+	draw.Draw(r.Image, r.Image.Bounds(), image.NewUniform(toNRGBA(c)), image.Point{}, draw.Src)
+}
+
+// draws every command in ctx's command list and clears it, the same way
+// ctx.Render does
+func (r *Renderer) Render(ctx *microui.Context) {
+	r.ResetClip()
+	ctx.Render(r.Draw)
+}
+
+// draws a single command, clipped to the rect of the last clip command
+func (r *Renderer) Draw(cmd *microui.Command) {
+	switch cmd.Type {
+	case microui.MU_COMMAND_CLIP:
+		r.clip = toRectangle(cmd.Clip.Rect).Intersect(r.Image.Bounds())
+	case microui.MU_COMMAND_RECT:
+		r.DrawRect(cmd.Rect.Rect, cmd.Rect.Color)
+	case microui.MU_COMMAND_TEXT:
+		r.DrawText(cmd.Text.Font, cmd.Text.Str, cmd.Text.Pos, cmd.Text.Color)
+	case microui.MU_COMMAND_ICON:
+		r.DrawIcon(cmd.Icon.Id, cmd.Icon.Rect, cmd.Icon.Color)
+	}
+}
+
+func (r *Renderer) DrawRect(rect microui.Rect, c microui.Color) {
+	dst := toRectangle(rect).Intersect(r.clip)
+	if dst.Empty() || c.A == 0 {
+		return
+	}
+	draw.Draw(r.Image, dst, image.NewUniform(toNRGBA(c)), image.Point{}, draw.Over)
+}
+
+func (r *Renderer) DrawText(f microui.Font, str string, pos microui.Vec2, c microui.Color) {
+	dst, ok := r.Image.SubImage(r.clip).(*image.RGBA)
+	if !ok || dst.Rect.Empty() {
+		return
+	}
+	face := r.face(f)
+	d := font.Drawer{
+		Dst:  dst,
+		Src:  image.NewUniform(toNRGBA(c)),
+		Face: face,
+		Dot:  fixed.P(pos.X, pos.Y+face.Metrics().Ascent.Ceil()),
+	}
+	d.DrawString(str)
+}
+
+// sets a single pixel, blending it with the existing one
+func (r *Renderer) blend(x, y int, c color.NRGBA) {
+	if !(image.Point{x, y}).In(r.clip) {
+		return
+	}
+	if c.A == 0xff {
+		r.Image.SetRGBA(x, y, color.RGBA{c.R, c.G, c.B, c.A})
+		return
+	}
+	dst := r.Image.RGBAAt(x, y)
+	a := uint32(c.A)
+	ia := 0xff - a
+	r.Image.SetRGBA(x, y, color.RGBA{
+		uint8((uint32(c.R)*a + uint32(dst.R)*ia) / 0xff),
+		uint8((uint32(c.G)*a + uint32(dst.G)*ia) / 0xff),
+		uint8((uint32(c.B)*a + uint32(dst.B)*ia) / 0xff),
+		uint8(a + uint32(dst.A)*ia/0xff),
+	})
+}
+
+func toRectangle(r microui.Rect) image.Rectangle {
+	return image.Rect(r.X, r.Y, r.X+r.W, r.Y+r.H)
+}
+
+func toNRGBA(c microui.Color) color.NRGBA {
+	return color.NRGBA{c.R, c.G, c.B, c.A}
+}
@@ -0,0 +1,142 @@
+package microui
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+)
+
+/*============================================================================
+** input recording and playback
+**============================================================================*/
+
+// a single call to one of the Input* handlers. Frame is the value of
+// ctx.Frame at the time of the call, relative to the frame the recording was
+// started on. input that is given between End() and Begin() is tagged with
+// the frame that just ended
+type InputEvent struct {
+	Frame  int     `json:"frame"`
+	Type   int     `json:"type"`
+	X      int     `json:"x,omitempty"`
+	Y      int     `json:"y,omitempty"`
+	Button int     `json:"button,omitempty"`
+	Key    int     `json:"key,omitempty"`
+	Text   string  `json:"text,omitempty"`
+	DT     float32 `json:"dt,omitempty"`
+}
+
+type InputRecording struct {
+	Events []InputEvent `json:"events"`
+}
+
+// starts recording every Input* call made on ctx. a recording that is already
+// in progress is discarded
+func (ctx *Context) StartRecording() {
+	ctx.recording = &InputRecording{}
+	ctx.recordStart = ctx.Frame
+}
+
+// stops recording and returns the recorded input, or nil if nothing was being
+// recorded
+func (ctx *Context) StopRecording() *InputRecording {
+	rec := ctx.recording
+	ctx.recording = nil
+	return rec
+}
+
+func (ctx *Context) record(ev InputEvent) {
+	if ctx.recording == nil {
+		return
+	}
+	ev.Frame = ctx.Frame - ctx.recordStart
+	ctx.recording.Events = append(ctx.recording.Events, ev)
+}
+
+// serializes the recording as JSON
+func (rec *InputRecording) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(rec)
+}
+
+func (rec *InputRecording) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := rec.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// reads a recording written by InputRecording.Save
+func LoadInputRecording(r io.Reader) (*InputRecording, error) {
+	rec := &InputRecording{}
+	if err := json.NewDecoder(r).Decode(rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+func LoadInputRecordingFile(path string) (*InputRecording, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadInputRecording(f)
+}
+
+// replays an InputRecording into a Context
+type InputPlayer struct {
+	rec        *InputRecording
+	idx        int
+	startFrame int
+	started    bool
+}
+
+func NewInputPlayer(rec *InputRecording) *InputPlayer {
+	return &InputPlayer{rec: rec}
+}
+
+// feeds every event that was recorded for the current frame into ctx. it
+// should be called once per frame, right before ctx.Begin(), starting with
+// the frame the recording should be replayed from
+func (p *InputPlayer) Play(ctx *Context) {
+	if !p.started {
+		p.startFrame = ctx.Frame
+		p.started = true
+	}
+	frame := ctx.Frame - p.startFrame
+	for ; p.idx < len(p.rec.Events); p.idx++ {
+		ev := p.rec.Events[p.idx]
+		if ev.Frame > frame {
+			break
+		}
+		switch ev.Type {
+		case MU_INPUT_MOUSEMOVE:
+			ctx.InputMouseMove(ev.X, ev.Y)
+		case MU_INPUT_MOUSEDOWN:
+			ctx.InputMouseDown(ev.X, ev.Y, ev.Button)
+		case MU_INPUT_MOUSEUP:
+			ctx.InputMouseUp(ev.X, ev.Y, ev.Button)
+		case MU_INPUT_SCROLL:
+			ctx.InputScroll(ev.X, ev.Y)
+		case MU_INPUT_KEYDOWN:
+			ctx.InputKeyDown(ev.Key)
+		case MU_INPUT_KEYUP:
+			ctx.InputKeyUp(ev.Key)
+		case MU_INPUT_TEXT:
+			ctx.InputText([]rune(ev.Text))
+		case MU_INPUT_TIME:
+			ctx.InputTime(ev.DT)
+		}
+	}
+}
+
+// reports whether every event has been replayed
+func (p *InputPlayer) Done() bool {
+	return p.idx >= len(p.rec.Events)
+}
@@ -0,0 +1,67 @@
+package microui
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// records clicking a checkbox and typing into a textbox, saves the recording
+// as JSON and replays it into a fresh context, which has to end up in the
+// same state
+func TestRecordingRoundTrip(t *testing.T) {
+	type state struct {
+		checked bool
+		text    string
+	}
+	newUI := func(ctx *Context, s *state) func() {
+		return func() {
+			ctx.LayoutRow(1, []int{-1}, 0)
+			ctx.Checkbox("check", &s.checked)
+			ctx.TextBox(&s.text)
+		}
+	}
+
+	ctx := newTestContext()
+	var got state
+	ui := newUI(ctx, &got)
+	testFrame(ctx, ui)
+	ctx.StartRecording()
+	testClick(ctx, ui, Vec2{15, 35})
+	testClick(ctx, ui, Vec2{50, 55})
+	ctx.InputText([]rune("héllo"))
+	ctx.InputTime(0.25)
+	testFrame(ctx, ui)
+	testKey(ctx, ui, MU_KEY_BACKSPACE)
+	ctx.InputScroll(0, 10)
+	testFrame(ctx, ui)
+	rec := ctx.StopRecording()
+	if !got.checked || got.text != "héll" {
+		t.Fatalf("recorded session gave %+v", got)
+	}
+
+	var buf bytes.Buffer
+	if err := rec.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadInputRecording(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, rec) {
+		t.Fatalf("loaded recording differs:\n%+v\nwant\n%+v", loaded, rec)
+	}
+
+	ctx = newTestContext()
+	var replayed state
+	ui = newUI(ctx, &replayed)
+	testFrame(ctx, ui)
+	p := NewInputPlayer(loaded)
+	for !p.Done() {
+		p.Play(ctx)
+		testFrame(ctx, ui)
+	}
+	if replayed != got {
+		t.Errorf("replay gave %+v, want %+v", replayed, got)
+	}
+}
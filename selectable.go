@@ -0,0 +1,116 @@
+package microui
+
+import (
+	"sort"
+	"strconv"
+)
+
+/*============================================================================
+** selectable and list box
+**============================================================================*/
+
+// does a full-width row that can be clicked, drawn highlighted if selected.
+// returns true if it was clicked or activated with the keyboard
+func (ctx *Context) selectableRow(id mu_Id, label string, selected bool, opt int) bool {
+	r := ctx.LayoutNext()
+	ctx.UpdateControl(id, r, opt)
+	clicked := (ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id) || ctx.KeyActivated(id)
+
+	// draw
+	if selected {
+		ctx.DrawFrame(ctx, r, MU_COLOR_BUTTONFOCUS)
+	} else if ctx.Hover == id {
+		ctx.DrawFrame(ctx, r, MU_COLOR_BUTTONHOVER)
+	}
+	ctx.DrawFocusRing(id, r)
+	ctx.DrawControlText(label, r, MU_COLOR_TEXT, opt)
+	return clicked
+}
+
+// a row that toggles *selected when clicked. returns MU_RES_CHANGE if
+// *selected changed
+func (ctx *Context) Selectable(label string, selected *bool, opt int) int {
+	var res int = 0
+	id := ctx.GetID([]byte(label))
+	if ctx.selectableRow(id, label, *selected, opt) {
+		*selected = !*selected
+		res |= MU_RES_CHANGE
+	}
+	return res
+}
+
+func containsInt(s []int, v int) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// a scrollable list of items in the next layout rect. *selection holds the
+// indices of the selected items in ascending order. clicking an item selects
+// only it; if multi is true ctrl+click toggles an item and shift+click selects
+// the range from the last clicked item. returns MU_RES_CHANGE when the
+// selection changed
+func (ctx *Context) ListBox(name string, selection *[]int, items []string, multi bool) int {
+	var res int = 0
+	listID := ctx.GetID([]byte(name))
+	ctx.BeginPanelEx(name, 0)
+	ctx.LayoutRow(1, []int{-1}, 0)
+	for i, item := range items {
+		iid := ctx.GetID([]byte("!item" + strconv.Itoa(i)))
+		selected := containsInt(*selection, i)
+		if !ctx.selectableRow(iid, item, selected, 0) {
+			continue
+		}
+		before := append([]int(nil), *selection...)
+		ctrl := multi && (ctx.KeyDown&MU_KEY_CTRL) != 0
+		shift := multi && (ctx.KeyDown&MU_KEY_SHIFT) != 0 && ctx.listAnchorID == listID
+		switch {
+		case shift:
+			lo, hi := ctx.listAnchor, i
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			*selection = (*selection)[:0]
+			for j := lo; j <= hi && j < len(items); j++ {
+				*selection = append(*selection, j)
+			}
+		case ctrl && selected:
+			s := (*selection)[:0]
+			for _, j := range *selection {
+				if j != i {
+					s = append(s, j)
+				}
+			}
+			*selection = s
+		case ctrl:
+			*selection = append(*selection, i)
+			sort.Ints(*selection)
+		default:
+			*selection = append((*selection)[:0], i)
+		}
+		if !shift {
+			ctx.listAnchorID = listID
+			ctx.listAnchor = i
+		}
+		if !equalInts(before, *selection) {
+			res |= MU_RES_CHANGE
+		}
+	}
+	ctx.EndPanel()
+	return res
+}
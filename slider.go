@@ -0,0 +1,125 @@
+package microui
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+/*============================================================================
+** slider helpers and range slider
+**============================================================================*/
+
+// returns the slider value under the mouse, snapped to multiples of step if
+// it isn't 0
+func (ctx *Context) sliderValueAt(base Rect, low, high, step float64, opt int) float64 {
+	var v float64
+	if (opt & MU_OPT_VERTICAL) != 0 {
+		v = low + float64(base.Y+base.H-ctx.MousePos.Y)*(high-low)/float64(base.H)
+	} else {
+		v = low + float64(ctx.MousePos.X-base.X)*(high-low)/float64(base.W)
+	}
+	if step != 0 {
+		v = float64(int64((v+step/2)/step)) * step
+	}
+	return v
+}
+
+// returns the rect of the thumb for value v of a slider
+func (ctx *Context) sliderThumb(base Rect, v, low, high float64, opt int) Rect {
+	w := ctx.Style.ThumbSize
+	if (opt & MU_OPT_VERTICAL) != 0 {
+		y := 0
+		if high > low {
+			y = int((v - low) * float64(base.H-w) / (high - low))
+		}
+		return NewRect(base.X, base.Y+base.H-w-y, base.W, w)
+	}
+	x := 0
+	if high > low {
+		x = int((v - low) * float64(base.W-w) / (high - low))
+	}
+	return NewRect(base.X+x, base.Y, w, base.H)
+}
+
+// a slider with two thumbs selecting the range *lo to *hi between low and
+// high. each half of the track between the thumbs moves the closer thumb,
+// the thumbs can't be dragged past each other. supports MU_OPT_VERTICAL like
+// SliderEx
+func (ctx *Context) RangeSliderEx(lo, hi *float32, low, high, step float32, format string, opt int) int {
+	var res int = 0
+	ctx.PushID(unsafe.Slice((*byte)(unsafe.Pointer(&lo)), unsafe.Sizeof(lo)))
+	loID := ctx.GetID([]byte("!lo"))
+	hiID := ctx.GetID([]byte("!hi"))
+	ctx.PopID()
+	base := ctx.LayoutNext()
+	lastLo, lastHi := *lo, *hi
+	flow, fhigh := float64(low), float64(high)
+
+	// split the track halfway between the thumbs
+	lot := ctx.sliderThumb(base, float64(*lo), flow, fhigh, opt)
+	hit := ctx.sliderThumb(base, float64(*hi), flow, fhigh, opt)
+	lor, hir := base, base
+	if (opt & MU_OPT_VERTICAL) != 0 {
+		mid := (lot.Y + lot.H + hit.Y) / 2
+		hir.H = mid - base.Y
+		lor.Y, lor.H = mid, base.Y+base.H-mid
+	} else {
+		mid := (lot.X + hit.X + hit.W) / 2
+		lor.W = mid - base.X
+		hir.X, hir.W = mid, base.X+base.W-mid
+	}
+
+	// handle input
+	ctx.UpdateControl(loID, lor, opt)
+	ctx.UpdateControl(hiID, hir, opt)
+	dragging := (ctx.MouseDown | ctx.MousePressed) == MU_MOUSE_LEFT
+	if ctx.Focus == loID && dragging {
+		*lo = float32(ctx.sliderValueAt(base, flow, fhigh, float64(step), opt))
+	}
+	if ctx.Focus == hiID && dragging {
+		*hi = float32(ctx.sliderValueAt(base, flow, fhigh, float64(step), opt))
+	}
+	// clamp and store values, update res
+	*lo = mu_clamp_real(*lo, low, high)
+	*hi = mu_clamp_real(*hi, low, high)
+	// the dragged thumb stops at the other one
+	if ctx.Focus == loID {
+		*lo = mu_min_real(*lo, *hi)
+	} else {
+		*hi = mu_max_real(*hi, *lo)
+	}
+	if *lo != lastLo || *hi != lastHi {
+		res |= MU_RES_CHANGE
+	}
+	undoTrackValue(ctx, loID, lo, lastLo)
+	undoTrackValue(ctx, hiID, hi, lastHi)
+
+	// draw base and the selected range
+	baseID := loID
+	if ctx.Focus == hiID || (ctx.Focus != loID && ctx.Hover == hiID) {
+		baseID = hiID
+	}
+	ctx.DrawControlFrame(baseID, base, MU_COLOR_BASE, opt)
+	ctx.DrawFocusRing(loID, base)
+	ctx.DrawFocusRing(hiID, base)
+	lot = ctx.sliderThumb(base, float64(*lo), flow, fhigh, opt)
+	hit = ctx.sliderThumb(base, float64(*hi), flow, fhigh, opt)
+	var sel Rect
+	if (opt & MU_OPT_VERTICAL) != 0 {
+		sel = NewRect(base.X, hit.Y+hit.H, base.W, lot.Y-hit.Y-hit.H)
+	} else {
+		sel = NewRect(lot.X+lot.W, base.Y, hit.X-lot.X-lot.W, base.H)
+	}
+	if sel.W > 0 && sel.H > 0 {
+		ctx.DrawRect(sel, ctx.Style.Colors[MU_COLOR_BASEFOCUS])
+	}
+	// draw thumbs
+	ctx.DrawControlFrame(loID, lot, MU_COLOR_BUTTON, opt)
+	ctx.DrawControlFrame(hiID, hit, MU_COLOR_BUTTON, opt)
+	// draw text
+	text := fmt.Sprintf(format, *lo) + " - " + fmt.Sprintf(format, *hi)
+	ctx.DrawControlText(text, base, MU_COLOR_TEXT, opt)
+
+	ctx.LastID = hiID
+	return res
+}
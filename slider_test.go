@@ -0,0 +1,40 @@
+package microui
+
+import "testing"
+
+// drags each thumb of a range slider past the other one, the dragged thumb
+// has to stop at the other one while the other one stays where it is
+func TestRangeSliderBlocks(t *testing.T) {
+	for _, dragHi := range []bool{false, true} {
+		ctx := newTestContext()
+		lo, hi := float32(40), float32(60)
+		var r Rect
+		ui := func() {
+			ctx.LayoutRow(1, []int{-1}, 0)
+			ctx.RangeSlider(&lo, &hi, 0, 100)
+			r = ctx.LastRect
+		}
+		testFrame(ctx, ui)
+		thumb := ctx.sliderThumb(r, float64(lo), 0, 100, 0)
+		to := r.X + r.W - 1
+		if dragHi {
+			thumb = ctx.sliderThumb(r, float64(hi), 0, 100, 0)
+			to = r.X
+		}
+		p := Vec2{thumb.X + thumb.W/2, thumb.Y + thumb.H/2}
+		ctx.InputMouseMove(p.X, p.Y)
+		testFrame(ctx, ui)
+		testFrame(ctx, ui)
+		ctx.InputMouseDown(p.X, p.Y, MU_MOUSE_LEFT)
+		testFrame(ctx, ui)
+		ctx.InputMouseMove(to, p.Y)
+		testFrame(ctx, ui)
+		ctx.InputMouseUp(to, p.Y, MU_MOUSE_LEFT)
+		testFrame(ctx, ui)
+		if dragHi && (lo != 40 || hi != 40) {
+			t.Errorf("dragging hi past lo gave %v - %v, want 40 - 40", lo, hi)
+		} else if !dragHi && (lo != 60 || hi != 60) {
+			t.Errorf("dragging lo past hi gave %v - %v, want 60 - 60", lo, hi)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/zeozeozeo/microui-go/snapshot
+
+go 1.19
+
+require (
+	github.com/zeozeozeo/microui-go v0.0.0
+	github.com/zeozeozeo/microui-go/raster v0.0.0
+)
+
+require golang.org/x/image v0.18.0 // indirect
+
+replace (
+	github.com/zeozeozeo/microui-go => ../
+	github.com/zeozeozeo/microui-go/raster => ../raster
+)
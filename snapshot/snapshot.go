@@ -0,0 +1,208 @@
+// Package snapshot is a golden-image testing helper for UIs built with
+// microui. A Harness drives a microui.Context through scripted frames,
+// rasterizes the resulting command list with the raster package and compares
+// it against PNG files stored on disk.
+//
+//	h := snapshot.New(320, 240)
+//	img := h.Frames(2, func(ctx *microui.Context) {
+//		if ctx.BeginWindow("Window", microui.NewRect(10, 10, 200, 150)) {
+//			ctx.Button("OK")
+//			ctx.EndWindow()
+//		}
+//	})
+//	h.Assert(t, "window", img)
+//
+// Goldens are written instead of compared when Harness.Update is set or the
+// UPDATE_GOLDEN environment variable is non-empty.
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	microui "github.com/zeozeozeo/microui-go"
+	"github.com/zeozeozeo/microui-go/raster"
+)
+
+// environment variable that makes Assert write goldens instead of comparing
+const UpdateEnv = "UPDATE_GOLDEN"
+
+type Harness struct {
+	Ctx        *microui.Context
+	Renderer   *raster.Renderer
+	Background microui.Color
+
+	// directory the goldens are stored in, "testdata" by default
+	Dir string
+	// maximum difference of a single color channel for two pixels to be
+	// considered equal
+	Tolerance int
+	// maximum number of differing pixels that is still accepted
+	MaxDiffPixels int
+	// write goldens instead of comparing against them
+	Update bool
+}
+
+// creates a new harness with a fresh context that renders into a w*h image
+func New(w, h int) *Harness {
+	hs := &Harness{
+		Ctx:        microui.NewContext(),
+		Renderer:   raster.NewRenderer(w, h),
+		Background: microui.NewColor(0, 0, 0, 255),
+		Dir:        "testdata",
+		Update:     os.Getenv(UpdateEnv) != "",
+	}
+	hs.Renderer.Attach(hs.Ctx)
+	return hs
+}
+
+// runs a single frame: calls ctx.Begin, ui and ctx.End, then rasterizes the
+// command list. the returned image is owned by the renderer and is
+// overwritten by the next frame
+func (h *Harness) Frame(ui func(ctx *microui.Context)) *image.RGBA {
+	h.Ctx.Begin()
+	ui(h.Ctx)
+	h.Ctx.End()
+	h.Renderer.Clear(h.Background)
+	h.Renderer.Render(h.Ctx)
+	return h.Renderer.Image
+}
+
+// runs n frames with the same ui function and returns the image of the last
+// one. microui needs a few frames to settle things like hover state and
+// content sizes, so most snapshots should be taken after at least 2 frames
+func (h *Harness) Frames(n int, ui func(ctx *microui.Context)) *image.RGBA {
+	var img *image.RGBA
+	for i := 0; i < n; i++ {
+		img = h.Frame(ui)
+	}
+	return img
+}
+
+// compares img against the golden <Dir>/<name>.png. on mismatch the test
+// fails and the rendered image and a diff image are written next to the
+// golden as <name>.got.png and <name>.diff.png
+func (h *Harness) Assert(t testing.TB, name string, img image.Image) {
+	t.Helper()
+	path := filepath.Join(h.Dir, name+".png")
+	if h.Update {
+		if err := os.MkdirAll(h.Dir, 0o755); err != nil {
+			t.Fatalf("snapshot: %v", err)
+		}
+		if err := WritePNG(path, img); err != nil {
+			t.Fatalf("snapshot: %v", err)
+		}
+		return
+	}
+
+	want, err := ReadPNG(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("snapshot: golden %s does not exist, run with %s=1 to create it", path, UpdateEnv)
+	} else if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	diff, n := Compare(want, img, h.Tolerance)
+	if n <= h.MaxDiffPixels {
+		return
+	}
+	gotPath := filepath.Join(h.Dir, name+".got.png")
+	diffPath := filepath.Join(h.Dir, name+".diff.png")
+	if err := WritePNG(gotPath, img); err != nil {
+		t.Errorf("snapshot: %v", err)
+	}
+	if err := WritePNG(diffPath, diff); err != nil {
+		t.Errorf("snapshot: %v", err)
+	}
+	t.Errorf("snapshot: %s differs from golden in %d pixels (tolerance %d, max %d), see %s",
+		name, n, h.Tolerance, h.MaxDiffPixels, diffPath)
+}
+
+// compares two images pixel by pixel and returns an image that highlights
+// differing pixels in red along with the number of differing pixels. pixels
+// whose channels all differ by at most tolerance are considered equal. if
+// the sizes differ, every pixel outside the common area counts as different
+func Compare(want, got image.Image, tolerance int) (*image.RGBA, int) {
+	wb, gb := want.Bounds(), got.Bounds()
+	bounds := image.Rect(0, 0, max(wb.Dx(), gb.Dx()), max(wb.Dy(), gb.Dy()))
+	diff := image.NewRGBA(bounds)
+	n := 0
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			wp := image.Pt(wb.Min.X+x, wb.Min.Y+y)
+			gp := image.Pt(gb.Min.X+x, gb.Min.Y+y)
+			if !wp.In(wb) || !gp.In(gb) {
+				diff.SetRGBA(x, y, color.RGBA{255, 0, 0, 255})
+				n++
+				continue
+			}
+			wc := color.NRGBAModel.Convert(want.At(wp.X, wp.Y)).(color.NRGBA)
+			gc := color.NRGBAModel.Convert(got.At(gp.X, gp.Y)).(color.NRGBA)
+			if channelDiff(wc, gc) > tolerance {
+				diff.SetRGBA(x, y, color.RGBA{255, 0, 0, 255})
+				n++
+			} else {
+				// dim the matching pixels so that the differences stand out
+				diff.SetRGBA(x, y, color.RGBA{wc.R / 4, wc.G / 4, wc.B / 4, 255})
+			}
+		}
+	}
+	return diff, n
+}
+
+func channelDiff(a, b color.NRGBA) int {
+	d := 0
+	for _, v := range [4]int{
+		int(a.R) - int(b.R),
+		int(a.G) - int(b.G),
+		int(a.B) - int(b.B),
+		int(a.A) - int(b.A),
+	} {
+		if v < 0 {
+			v = -v
+		}
+		if v > d {
+			d = v
+		}
+	}
+	return d
+}
+
+func ReadPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return img, nil
+}
+
+func WritePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
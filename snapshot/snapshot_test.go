@@ -0,0 +1,92 @@
+package snapshot
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	microui "github.com/zeozeozeo/microui-go"
+)
+
+func TestWindowScrolled(t *testing.T) {
+	h := New(240, 180)
+	ui := func(ctx *microui.Context) {
+		if ctx.BeginWindow("Window", microui.NewRect(10, 10, 200, 150)) {
+			ctx.LayoutRow(1, []int{-1}, 0)
+			for i := 0; i < 20; i++ {
+				ctx.Label(fmt.Sprintf("line %d", i))
+			}
+			ctx.EndWindow()
+		}
+	}
+	h.Ctx.InputMouseMove(100, 100)
+	h.Frames(2, ui)
+	h.Ctx.InputScroll(0, 60)
+	img := h.Frames(2, ui)
+	h.Assert(t, "window_scrolled", img)
+}
+
+func TestLayoutRowsColumns(t *testing.T) {
+	h := New(320, 200)
+	img := h.Frames(2, func(ctx *microui.Context) {
+		opt := microui.MU_OPT_NOSCROLL
+		if ctx.BeginWindowEx("Layout", microui.NewRect(10, 10, 300, 180), opt) != 0 {
+			// fixed, relative to the right edge and fill widths
+			ctx.LayoutRow(3, []int{60, -80, -1}, 0)
+			ctx.Button("fixed")
+			ctx.Button("fill")
+			ctx.Button("rest")
+			// a column next to a taller item
+			ctx.LayoutRow(2, []int{100, -1}, 60)
+			ctx.LayoutBeginColumn()
+			ctx.LayoutRow(1, []int{-1}, 0)
+			ctx.Button("a")
+			ctx.Button("b")
+			ctx.LayoutEndColumn()
+			ctx.Button("tall")
+			// rows wrap after the given number of items
+			ctx.LayoutRow(2, []int{-150, -1}, 0)
+			for i := 0; i < 4; i++ {
+				ctx.Button(fmt.Sprintf("item %d", i))
+			}
+			ctx.EndWindow()
+		}
+	})
+	h.Assert(t, "layout_rows_columns", img)
+}
+
+func TestCompare(t *testing.T) {
+	want := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	got := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for _, img := range []*image.RGBA{want, got} {
+		draw.Draw(img, img.Bounds(), image.NewUniform(color.Black), image.Point{}, draw.Src)
+	}
+	got.SetRGBA(1, 1, color.RGBA{3, 0, 0, 255})
+	got.SetRGBA(2, 2, color.RGBA{0, 10, 0, 255})
+
+	tests := []struct {
+		tolerance int
+		want      int
+	}{
+		{0, 2},
+		{3, 1},
+		{10, 0},
+	}
+	for _, tt := range tests {
+		diff, n := Compare(want, got, tt.tolerance)
+		if n != tt.want {
+			t.Errorf("tolerance %d: %d differing pixels, want %d", tt.tolerance, n, tt.want)
+		}
+		if red := diff.RGBAAt(2, 2) == (color.RGBA{255, 0, 0, 255}); red != (tt.tolerance < 10) {
+			t.Errorf("tolerance %d: pixel 2,2 highlighted = %v", tt.tolerance, red)
+		}
+	}
+
+	// pixels outside of the smaller image always differ
+	small := image.NewRGBA(image.Rect(0, 0, 4, 3))
+	if _, n := Compare(want, small, 255); n != 4 {
+		t.Errorf("size mismatch: %d differing pixels, want 4", n)
+	}
+}
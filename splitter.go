@@ -0,0 +1,43 @@
+package microui
+
+/*============================================================================
+** splitter
+**============================================================================*/
+
+// a handle in the next layout rect that resizes the two regions next to it
+// when dragged, moving space between *size1 and *size2 while keeping both at
+// least minSize. if vertical is true the regions are stacked and the handle
+// is dragged up and down, otherwise they are side by side. returns
+// MU_RES_CHANGE when the sizes changed
+func (ctx *Context) Splitter(id string, size1, size2 *int, vertical bool, minSize int) int {
+	var res int = 0
+	hid := ctx.GetID([]byte(id))
+	r := ctx.LayoutNext()
+	ctx.UpdateControl(hid, r, MU_OPT_NONAV)
+
+	// handle input
+	if ctx.Focus == hid && ctx.MouseDown == MU_MOUSE_LEFT {
+		d := ctx.MouseDelta.X
+		if vertical {
+			d = ctx.MouseDelta.Y
+		}
+		// don't shrink a region that is already below the minimum
+		d = mu_clamp(d, mu_min(minSize-*size1, 0), mu_max(*size2-minSize, 0))
+		if d != 0 {
+			*size1 += d
+			*size2 -= d
+			res |= MU_RES_CHANGE
+		}
+	}
+
+	// draw
+	colorid := MU_COLOR_SCROLLBASE
+	if ctx.Focus == hid {
+		colorid = MU_COLOR_BUTTONFOCUS
+	} else if ctx.Hover == hid {
+		colorid = MU_COLOR_BUTTONHOVER
+	}
+	ctx.DrawFrame(ctx, r, colorid)
+
+	return res
+}
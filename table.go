@@ -0,0 +1,209 @@
+package microui
+
+import "strconv"
+
+/*============================================================================
+** table
+**============================================================================*/
+
+// begins a table with the given number of columns. column widths and the sort
+// state are remembered in ctx.TablePool. pass MU_OPT_SORTABLE to allow
+// sorting by clicking the column headers, see TableSortSpecs
+func (ctx *Context) BeginTable(name string, columns int, opt int) {
+	expect(columns > 0)
+	ctx.PushID([]byte(name))
+	id := ctx.LastID
+	idx := ctx.PoolGet(ctx.TablePool[:], id)
+	if idx >= 0 {
+		ctx.PoolUpdate(ctx.TablePool[:], idx)
+	} else {
+		idx = ctx.PoolInit(ctx.TablePool[:], id)
+		ctx.Tables[idx] = Table{SortColumn: -1}
+	}
+	table := &ctx.Tables[idx]
+	table.Columns = table.Columns[:0]
+	table.count = columns
+	table.Opt = opt
+	table.header = false
+	table.column = -1
+	// push()
+	ctx.TableStack = append(ctx.TableStack, table)
+}
+
+func (ctx *Context) EndTable() {
+	table := ctx.currentTable()
+	if !table.header {
+		ctx.tableHeader(table)
+	}
+	// pop()
+	ctx.TableStack = ctx.TableStack[:len(ctx.TableStack)-1]
+	ctx.PopID()
+}
+
+func (ctx *Context) currentTable() *Table {
+	expect(len(ctx.TableStack) > 0)
+	return ctx.TableStack[len(ctx.TableStack)-1]
+}
+
+// sets up the next column of the current table, must be called before the
+// first row. widthWeight is the column's initial share of the table width
+// (0 counts as 1), it can be resized unless flags has MU_OPT_NORESIZE. the
+// MU_OPT_ALIGNCENTER and MU_OPT_ALIGNRIGHT flags align the header label
+func (ctx *Context) TableSetupColumn(label string, widthWeight float32, flags int) {
+	table := ctx.currentTable()
+	expect(!table.header && len(table.Columns) < table.count)
+	table.Columns = append(table.Columns, TableColumn{label, widthWeight, flags})
+}
+
+// returns the column the table is sorted by (-1 if none) and the direction.
+// dirty is true if the sort was changed since the last call, in which case
+// the data should be sorted again
+func (ctx *Context) TableSortSpecs() (column int, descending bool, dirty bool) {
+	table := ctx.currentTable()
+	dirty = table.sortDirty
+	table.sortDirty = false
+	return table.SortColumn, table.SortDescending, dirty
+}
+
+// returns the rect of column i in the given row
+func (ctx *Context) tableColumnRect(table *Table, row Rect, i int) Rect {
+	var x0, x1 float32
+	for j := 0; j < i; j++ {
+		x0 += table.Widths[j]
+	}
+	x1 = x0 + table.Widths[i]
+	left := row.X + int(x0*float32(row.W)+0.5)
+	right := row.X + int(x1*float32(row.W)+0.5)
+	if i == len(table.Widths)-1 {
+		right = row.X + row.W
+	} else {
+		right -= ctx.Style.Spacing
+	}
+	return NewRect(left, row.Y, right-left, row.H)
+}
+
+// lays out the header row, handling column resizing and sorting
+func (ctx *Context) tableHeader(table *Table) {
+	table.header = true
+	for len(table.Columns) < table.count {
+		table.Columns = append(table.Columns, TableColumn{Weight: 1})
+	}
+	// compute the initial widths from the weights
+	if len(table.Widths) != table.count {
+		var sum float32
+		table.Widths = table.Widths[:0]
+		for _, c := range table.Columns {
+			w := c.Weight
+			if w <= 0 {
+				w = 1
+			}
+			table.Widths = append(table.Widths, w)
+			sum += w
+		}
+		for i := range table.Widths {
+			table.Widths[i] /= sum
+		}
+	}
+
+	// the header is only shown if a column has a label
+	show := false
+	for _, c := range table.Columns {
+		show = show || len(c.Label) > 0
+	}
+	if !show {
+		return
+	}
+	ctx.LayoutRow(1, []int{-1}, 0)
+	row := ctx.LayoutNext()
+
+	for i, c := range table.Columns {
+		r := ctx.tableColumnRect(table, row, i)
+		id := ctx.GetID([]byte("!header" + strconv.Itoa(i)))
+		ctx.UpdateControl(id, r, MU_OPT_NONAV)
+
+		// handle sorting
+		sortable := (table.Opt & MU_OPT_SORTABLE) != 0
+		if sortable && ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id {
+			if table.SortColumn == i {
+				table.SortDescending = !table.SortDescending
+			} else {
+				table.SortColumn = i
+				table.SortDescending = false
+			}
+			table.sortDirty = true
+		}
+
+		// draw
+		colorid := MU_COLOR_BUTTON
+		if ctx.Hover == id && sortable {
+			colorid = MU_COLOR_BUTTONHOVER
+		}
+		ctx.DrawFrame(ctx, r, colorid)
+		tr := r
+		if table.SortColumn == i {
+			tr.W -= r.H
+			ctx.drawSortArrow(NewRect(r.X+r.W-r.H, r.Y, r.H, r.H), table.SortDescending)
+		}
+		ctx.DrawControlText(c.Label, tr, MU_COLOR_TEXT, c.Opt)
+	}
+
+	// handle resizing by dragging the borders between the columns
+	minw := float32(ctx.Style.Size.Y) / float32(mu_max(row.W, 1))
+	for i := 0; i+1 < len(table.Columns); i++ {
+		if (table.Columns[i].Opt|table.Columns[i+1].Opt)&MU_OPT_NORESIZE != 0 {
+			continue
+		}
+		r := ctx.tableColumnRect(table, row, i)
+		handle := NewRect(r.X+r.W-2, r.Y, ctx.Style.Spacing+4, r.H)
+		id := ctx.GetID([]byte("!border" + strconv.Itoa(i)))
+		ctx.UpdateControl(id, handle, MU_OPT_NONAV)
+		if ctx.Focus == id && ctx.MouseDown == MU_MOUSE_LEFT && row.W > 0 {
+			d := float32(ctx.MouseDelta.X) / float32(row.W)
+			// move width from one column to the other, keeping both above
+			// the minimum
+			d = mu_clamp_real(d, minw-table.Widths[i], table.Widths[i+1]-minw)
+			table.Widths[i] += d
+			table.Widths[i+1] -= d
+		}
+	}
+}
+
+// draws a small triangle pointing up, or down if descending is true
+func (ctx *Context) drawSortArrow(r Rect, descending bool) {
+	color := ctx.Style.Colors[MU_COLOR_TEXT]
+	h := mu_max(r.H/4, 2)
+	y := r.Y + (r.H-h)/2
+	cx := r.X + r.W/2
+	for i := 0; i < h; i++ {
+		w := i
+		if descending {
+			w = h - 1 - i
+		}
+		ctx.DrawRect(NewRect(cx-w, y+i, w*2+1, 1), color)
+	}
+}
+
+// starts a new row in the current table. the header row is added before the
+// first row
+func (ctx *Context) TableNextRow() {
+	table := ctx.currentTable()
+	if !table.header {
+		ctx.tableHeader(table)
+	}
+	ctx.LayoutRow(1, []int{-1}, 0)
+	table.row = ctx.LayoutNext()
+	table.column = -1
+}
+
+// moves to the next cell of the current row, the next control is placed in
+// it. returns false if the row has no cells left
+func (ctx *Context) TableNextColumn() bool {
+	table := ctx.currentTable()
+	expect(table.header)
+	if table.column+1 >= table.count {
+		return false
+	}
+	table.column++
+	ctx.LayoutSetNext(ctx.tableColumnRect(table, table.row, table.column), false)
+	return true
+}
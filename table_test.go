@@ -0,0 +1,142 @@
+package microui
+
+import (
+	"math"
+	"testing"
+)
+
+type testTable struct {
+	table  *Table
+	header Rect // the header row
+	dirty  int  // number of frames TableSortSpecs reported a change
+}
+
+// returns a ui with a table of three labelled columns and one row. colOpt is
+// passed to the middle column
+func testTableUI(ctx *Context, tt *testTable, opt int, colOpt int) func() {
+	return func() {
+		ctx.BeginTable("table", 3, opt)
+		ctx.TableSetupColumn("A", 1, 0)
+		ctx.TableSetupColumn("B", 1, colOpt)
+		ctx.TableSetupColumn("C", 1, 0)
+		ctx.TableNextRow()
+		tt.table = ctx.currentTable()
+		tt.header = tt.table.row
+		tt.header.Y -= tt.header.H + ctx.Style.Spacing
+		for ctx.TableNextColumn() {
+			ctx.Label("x")
+		}
+		if _, _, dirty := ctx.TableSortSpecs(); dirty {
+			tt.dirty++
+		}
+		ctx.EndTable()
+	}
+}
+
+func (tt *testTable) headerCenter(ctx *Context, i int) Vec2 {
+	r := ctx.tableColumnRect(tt.table, tt.header, i)
+	return Vec2{r.X + r.W/2, r.Y + r.H/2}
+}
+
+func TestTableSort(t *testing.T) {
+	ctx := newTestContext()
+	var tt testTable
+	ui := testTableUI(ctx, &tt, MU_OPT_SORTABLE, 0)
+	testFrame(ctx, ui)
+	if tt.table.SortColumn != -1 || tt.dirty != 0 {
+		t.Fatalf("new table sorted by %d, dirty %d times", tt.table.SortColumn, tt.dirty)
+	}
+
+	steps := []struct {
+		column     int // header to click
+		sortColumn int
+		descending bool
+	}{
+		{1, 1, false},
+		{1, 1, true},
+		{1, 1, false},
+		{0, 0, false},
+		{0, 0, true},
+		{2, 2, false},
+	}
+	for i, s := range steps {
+		testClick(ctx, ui, tt.headerCenter(ctx, s.column))
+		if tt.table.SortColumn != s.sortColumn || tt.table.SortDescending != s.descending {
+			t.Errorf("click %d on column %d: sorted by %d, descending %v, want %d, %v",
+				i, s.column, tt.table.SortColumn, tt.table.SortDescending, s.sortColumn, s.descending)
+		}
+		if tt.dirty != i+1 {
+			t.Errorf("click %d: sort reported as changed %d times, want %d", i, tt.dirty, i+1)
+		}
+	}
+}
+
+func TestTableNotSortable(t *testing.T) {
+	ctx := newTestContext()
+	var tt testTable
+	ui := testTableUI(ctx, &tt, 0, 0)
+	testFrame(ctx, ui)
+	testClick(ctx, ui, tt.headerCenter(ctx, 1))
+	if tt.table.SortColumn != -1 || tt.dirty != 0 {
+		t.Errorf("table without MU_OPT_SORTABLE sorted by %d, dirty %d times", tt.table.SortColumn, tt.dirty)
+	}
+}
+
+// drags the border on the right of column i by dx
+func testDragBorder(ctx *Context, ui func(), tt *testTable, i int, dx int) {
+	r := ctx.tableColumnRect(tt.table, tt.header, i)
+	p := Vec2{r.X + r.W - 2 + (ctx.Style.Spacing+4)/2, r.Y + r.H/2}
+	ctx.InputMouseMove(p.X, p.Y)
+	testFrame(ctx, ui)
+	testFrame(ctx, ui)
+	ctx.InputMouseDown(p.X, p.Y, MU_MOUSE_LEFT)
+	testFrame(ctx, ui)
+	ctx.InputMouseMove(p.X+dx, p.Y)
+	testFrame(ctx, ui)
+	ctx.InputMouseUp(p.X+dx, p.Y, MU_MOUSE_LEFT)
+	testFrame(ctx, ui)
+}
+
+func TestTableResize(t *testing.T) {
+	near := func(a, b float32) bool { return math.Abs(float64(a-b)) < 1e-4 }
+
+	ctx := newTestContext()
+	var tt testTable
+	ui := testTableUI(ctx, &tt, 0, 0)
+	// the window only knows its content size from the second frame on
+	testFrame(ctx, ui)
+	testFrame(ctx, ui)
+	rowW := float32(tt.header.W)
+	w := append([]float32(nil), tt.table.Widths...)
+
+	// widen the first column, the second one gives up the width
+	testDragBorder(ctx, ui, &tt, 0, 20)
+	got := tt.table.Widths
+	if !near(got[0], w[0]+20/rowW) || !near(got[1], w[1]-20/rowW) || !near(got[2], w[2]) {
+		t.Fatalf("after dragging by 20 widths are %v, was %v", got, w)
+	}
+
+	// dragging far to the left stops at the minimum width
+	testDragBorder(ctx, ui, &tt, 0, -1000)
+	minw := float32(ctx.Style.Size.Y) / rowW
+	if !near(got[0], minw) || !near(got[0]+got[1], w[0]+w[1]) {
+		t.Errorf("after dragging by -1000 widths are %v, want the first one at %v", got, minw)
+	}
+}
+
+func TestTableNoResize(t *testing.T) {
+	ctx := newTestContext()
+	var tt testTable
+	ui := testTableUI(ctx, &tt, 0, MU_OPT_NORESIZE)
+	testFrame(ctx, ui)
+	w := append([]float32(nil), tt.table.Widths...)
+
+	// both borders of the middle column are fixed
+	testDragBorder(ctx, ui, &tt, 0, 20)
+	testDragBorder(ctx, ui, &tt, 1, 20)
+	for i := range w {
+		if tt.table.Widths[i] != w[i] {
+			t.Fatalf("widths changed from %v to %v", w, tt.table.Widths)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package microui
+
+/*============================================================================
+** tab bar
+**============================================================================*/
+
+// begins a row of tabs. the selected tab is remembered in ctx.TabBarPool.
+// pass MU_OPT_REORDERABLE to allow reordering tabs by dragging them
+func (ctx *Context) BeginTabBarEx(name string, opt int) {
+	ctx.PushID([]byte(name))
+	id := ctx.LastID
+	idx := ctx.PoolGet(ctx.TabBarPool[:], id)
+	if idx >= 0 {
+		ctx.PoolUpdate(ctx.TabBarPool[:], idx)
+	} else {
+		idx = ctx.PoolInit(ctx.TabBarPool[:], id)
+		ctx.TabBars[idx] = TabBar{}
+	}
+	bar := &ctx.TabBars[idx]
+	bar.Opt = opt
+	ctx.LayoutRow(1, []int{-1}, 0)
+	bar.Rect = ctx.LayoutNext()
+	// push()
+	ctx.TabBarStack = append(ctx.TabBarStack, bar)
+}
+
+func (ctx *Context) EndTabBar() {
+	expect(len(ctx.TabBarStack) > 0)
+	bar := ctx.TabBarStack[len(ctx.TabBarStack)-1]
+	// forget tabs that weren't submitted this frame
+	items := bar.Items[:0]
+	selected := false
+	for _, item := range bar.Items {
+		if item.Frame == ctx.Frame {
+			items = append(items, item)
+			selected = selected || item.ID == bar.Selected
+		}
+	}
+	bar.Items = items
+	if !selected {
+		bar.Selected = 0
+		if len(items) > 0 {
+			bar.Selected = items[0].ID
+		}
+	}
+	// pop()
+	ctx.TabBarStack = ctx.TabBarStack[:len(ctx.TabBarStack)-1]
+	ctx.PopID()
+}
+
+func (bar *TabBar) index(id mu_Id) int {
+	for i := range bar.Items {
+		if bar.Items[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// adds a tab to the current tab bar. returns MU_RES_ACTIVE if the tab is
+// selected, its contents should be drawn after this call in that case. if
+// open is not nil the tab has a close button which sets *open to false; tabs
+// with *open == false are not shown
+func (ctx *Context) TabItemEx(label string, open *bool, opt int) int {
+	expect(len(ctx.TabBarStack) > 0)
+	bar := ctx.TabBarStack[len(ctx.TabBarStack)-1]
+	id := ctx.GetID([]byte(label))
+	if open != nil && !*open {
+		return 0
+	}
+	style := ctx.Style
+	font := style.Font
+
+	// get the tab's size and position
+	w := ctx.TextWidth(font, label) + style.Padding*2
+	if open != nil {
+		w += bar.Rect.H - style.Padding
+	}
+	i := bar.index(id)
+	if i < 0 {
+		bar.Items = append(bar.Items, TabBarItem{ID: id})
+		i = len(bar.Items) - 1
+	}
+	bar.Items[i].Width = w
+	bar.Items[i].Frame = ctx.Frame
+	x := bar.Rect.X
+	for j := 0; j < i; j++ {
+		x += bar.Items[j].Width + style.Spacing
+	}
+	r := NewRect(x, bar.Rect.Y, w, bar.Rect.H)
+	if bar.Selected == 0 {
+		bar.Selected = id
+	}
+
+	ctx.PushClipRect(bar.Rect)
+	ctx.UpdateControl(id, r, opt)
+
+	// do close button, it is updated after the tab so that it gets the hover
+	var cr Rect
+	if open != nil {
+		cid := ctx.GetID([]byte(label + "!close"))
+		cr = NewRect(r.X+r.W-r.H, r.Y, r.H, r.H)
+		ctx.UpdateControl(cid, cr, MU_OPT_NONAV)
+		if ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == cid {
+			*open = false
+			// treat it as not submitted so EndTabBar selects another tab
+			bar.Items[i].Frame = 0
+		}
+	}
+
+	// handle click
+	if (ctx.MousePressed == MU_MOUSE_LEFT && ctx.Focus == id) || ctx.KeyActivated(id) {
+		bar.Selected = id
+	}
+	// handle reordering by dragging the tab past the middle of its neighbour
+	if (bar.Opt&MU_OPT_REORDERABLE) != 0 && ctx.Focus == id && ctx.MouseDown == MU_MOUSE_LEFT {
+		if i > 0 && ctx.MousePos.X < x-style.Spacing-bar.Items[i-1].Width/2 {
+			bar.Items[i-1], bar.Items[i] = bar.Items[i], bar.Items[i-1]
+		} else if i+1 < len(bar.Items) && ctx.MousePos.X > x+w+style.Spacing+bar.Items[i+1].Width/2 {
+			bar.Items[i+1], bar.Items[i] = bar.Items[i], bar.Items[i+1]
+		}
+	}
+
+	// draw
+	colorid := MU_COLOR_BUTTON
+	if bar.Selected == id {
+		colorid = MU_COLOR_BUTTONFOCUS
+	} else if ctx.Hover == id {
+		colorid = MU_COLOR_BUTTONHOVER
+	}
+	ctx.DrawFrame(ctx, r, colorid)
+	ctx.DrawFocusRing(id, r)
+	tr := r
+	if open != nil {
+		tr.W -= cr.W - style.Padding
+		ctx.DrawIcon(MU_ICON_CLOSE, cr, style.Colors[MU_COLOR_TEXT])
+	}
+	ctx.DrawControlText(label, tr, MU_COLOR_TEXT, opt)
+	ctx.PopClipRect()
+
+	ctx.LastID = id
+	if open != nil && !*open {
+		return 0
+	}
+	if bar.Selected == id {
+		return MU_RES_ACTIVE
+	}
+	return 0
+}
@@ -0,0 +1,146 @@
+package microui
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testTabs struct {
+	labels []string
+	open   []bool
+	ids    []mu_Id
+	active string
+	bar    *TabBar
+}
+
+// returns a ui with a tab bar of the given tabs, closable tabs get an open
+// flag
+func testTabsUI(ctx *Context, tabs *testTabs, closable bool, opt int) func() {
+	tabs.open = make([]bool, len(tabs.labels))
+	tabs.ids = make([]mu_Id, len(tabs.labels))
+	for i := range tabs.open {
+		tabs.open[i] = true
+	}
+	return func() {
+		tabs.active = ""
+		ctx.BeginTabBarEx("tabs", opt)
+		for i, label := range tabs.labels {
+			var open *bool
+			if closable {
+				open = &tabs.open[i]
+			}
+			if ctx.TabItemEx(label, open, 0)&MU_RES_ACTIVE != 0 {
+				tabs.active = label
+			}
+			tabs.ids[i] = ctx.GetID([]byte(label))
+		}
+		tabs.bar = ctx.TabBarStack[len(ctx.TabBarStack)-1]
+		ctx.EndTabBar()
+	}
+}
+
+// returns the rect of the tab with the given label
+func (tabs *testTabs) rect(ctx *Context, label string) Rect {
+	bar := tabs.bar
+	x := bar.Rect.X
+	for _, item := range bar.Items {
+		if item.ID == tabs.ids[tabs.index(label)] {
+			return NewRect(x, bar.Rect.Y, item.Width, bar.Rect.H)
+		}
+		x += item.Width + ctx.Style.Spacing
+	}
+	return Rect{}
+}
+
+func (tabs *testTabs) index(label string) int {
+	for i, l := range tabs.labels {
+		if l == label {
+			return i
+		}
+	}
+	return -1
+}
+
+// returns the labels of the tabs in the order they are shown
+func (tabs *testTabs) order() []string {
+	var order []string
+	for _, item := range tabs.bar.Items {
+		for i, id := range tabs.ids {
+			if id == item.ID {
+				order = append(order, tabs.labels[i])
+			}
+		}
+	}
+	return order
+}
+
+func TestTabSelectAndClose(t *testing.T) {
+	ctx := newTestContext()
+	tabs := &testTabs{labels: []string{"one", "two", "three"}}
+	ui := testTabsUI(ctx, tabs, true, 0)
+	testFrame(ctx, ui)
+	if tabs.active != "one" {
+		t.Fatalf("active tab is %q, want the first one", tabs.active)
+	}
+
+	r := tabs.rect(ctx, "two")
+	testClick(ctx, ui, Vec2{r.X + 5, r.Y + r.H/2})
+	if tabs.active != "two" {
+		t.Fatalf("clicking a tab made %q active", tabs.active)
+	}
+
+	// closing the selected tab selects the first remaining one
+	r = tabs.rect(ctx, "two")
+	testClick(ctx, ui, Vec2{r.X + r.W - r.H/2, r.Y + r.H/2})
+	if tabs.open[1] || tabs.active != "one" {
+		t.Errorf("closing gave open = %v, active = %q, want false, one", tabs.open[1], tabs.active)
+	}
+	if got := tabs.order(); !reflect.DeepEqual(got, []string{"one", "three"}) {
+		t.Errorf("tabs after closing = %v", got)
+	}
+
+	// closing an unselected tab keeps the selection
+	r = tabs.rect(ctx, "three")
+	testClick(ctx, ui, Vec2{r.X + r.W - r.H/2, r.Y + r.H/2})
+	if tabs.open[2] || tabs.active != "one" {
+		t.Errorf("closing gave open = %v, active = %q, want false, one", tabs.open[2], tabs.active)
+	}
+}
+
+// drags a tab past the middle of its neighbours
+func TestTabReorder(t *testing.T) {
+	for _, opt := range []int{0, MU_OPT_REORDERABLE} {
+		ctx := newTestContext()
+		tabs := &testTabs{labels: []string{"one", "two", "three"}}
+		ui := testTabsUI(ctx, tabs, false, opt)
+		testFrame(ctx, ui)
+
+		r := tabs.rect(ctx, "one")
+		end := tabs.rect(ctx, "three")
+		p := Vec2{r.X + 5, r.Y + r.H/2}
+		ctx.InputMouseMove(p.X, p.Y)
+		testFrame(ctx, ui)
+		testFrame(ctx, ui)
+		ctx.InputMouseDown(p.X, p.Y, MU_MOUSE_LEFT)
+		testFrame(ctx, ui)
+		// move in steps, the tab moves one place per frame
+		for x := p.X; x <= end.X+end.W; x += 10 {
+			ctx.InputMouseMove(x, p.Y)
+			testFrame(ctx, ui)
+		}
+		ctx.InputMouseUp(end.X+end.W, p.Y, MU_MOUSE_LEFT)
+		testFrame(ctx, ui)
+
+		want := []string{"one", "two", "three"}
+		if opt != 0 {
+			want = []string{"two", "three", "one"}
+		}
+		got := tabs.order()
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("opt = %d: order after dragging = %v, want %v", opt, got, want)
+		}
+		if tabs.active != "one" {
+			t.Errorf("opt = %d: dragged tab isn't active", opt)
+		}
+	}
+}
@@ -0,0 +1,428 @@
+package microui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+/*============================================================================
+** text editing
+**============================================================================*/
+
+// reports whether r continues the grapheme cluster of the rune before it
+// instead of starting a new one. this is a simplified version of the rules
+// in UAX #29 that handles combining marks, variation selectors, emoji
+// modifiers and emoji tag sequences
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == 0x200c || // zero width non-joiner
+		(r >= 0xfe00 && r <= 0xfe0f) || // variation selectors
+		(r >= 0x1f3fb && r <= 0x1f3ff) || // emoji skin tone modifiers
+		(r >= 0xe0020 && r <= 0xe007f) || // tags
+		(r >= 0xe0100 && r <= 0xe01ef) // variation selectors supplement
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+// returns the position of the grapheme cluster after byte offset i
+func nextCharPos(s string, i int) int {
+	if i >= len(s) {
+		return len(s)
+	}
+	r, size := utf8.DecodeRuneInString(s[i:])
+	i += size
+	if r == '\r' && i < len(s) && s[i] == '\n' {
+		return i + 1
+	}
+	if isRegionalIndicator(r) {
+		// flags are made of two regional indicators
+		if r2, size := utf8.DecodeRuneInString(s[i:]); isRegionalIndicator(r2) {
+			i += size
+		}
+	}
+	for i < len(s) {
+		r, size = utf8.DecodeRuneInString(s[i:])
+		if r == 0x200d {
+			// zero width joiner: the next rune is part of this cluster too
+			i += size
+			if i < len(s) {
+				_, size = utf8.DecodeRuneInString(s[i:])
+				i += size
+			}
+		} else if isGraphemeExtend(r) {
+			i += size
+		} else {
+			break
+		}
+	}
+	return i
+}
+
+// returns the position of the grapheme cluster before byte offset i
+func prevCharPos(s string, i int) int {
+	// cluster boundaries can't be reliably found by walking backwards, so
+	// walk forward from the start instead
+	pos := 0
+	for pos < len(s) {
+		next := nextCharPos(s, pos)
+		if next >= i {
+			break
+		}
+		pos = next
+	}
+	return pos
+}
+
+// returns the start of the word before byte offset i
+func prevWordPos(s string, i int) int {
+	for i > 0 {
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		i -= size
+	}
+	for i > 0 {
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		if unicode.IsSpace(r) {
+			break
+		}
+		i -= size
+	}
+	return snapCharPos(s, i)
+}
+
+// returns the end of the word after byte offset i
+func nextWordPos(s string, i int) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if unicode.IsSpace(r) {
+			break
+		}
+		i += size
+	}
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		i += size
+	}
+	return snapCharPos(s, i)
+}
+
+// returns the last grapheme cluster boundary at or before byte offset i
+func snapCharPos(s string, i int) int {
+	if i >= len(s) {
+		return len(s)
+	}
+	return prevCharPos(s, nextCharPos(s, i))
+}
+
+// returns the character boundary in str that is closest to x pixels from the
+// start of the string
+func (ctx *Context) textIndexAt(font Font, str string, x int) int {
+	if x <= 0 {
+		return 0
+	}
+	last := 0
+	for i := 0; i < len(str); {
+		next := nextCharPos(str, i)
+		w := ctx.TextWidth(font, str[:next])
+		if w >= x {
+			// pick whichever side of the character is closer
+			if w-x < x-last {
+				return next
+			}
+			return i
+		}
+		last = w
+		i = next
+	}
+	return len(str)
+}
+
+// returns the largest grapheme cluster boundary in str[start:end] at which
+// str[start:idx] is at most w pixels wide. at least one cluster is always
+// included so that callers make progress
+func (ctx *Context) textFitIndex(font Font, str string, start, end, w int) int {
+	idx := nextCharPos(str, start)
+	for idx < end {
+		next := nextCharPos(str, idx)
+		if ctx.TextWidth(font, str[start:next]) > w {
+			break
+		}
+		idx = next
+	}
+	return mu_min(idx, end)
+}
+
+// returns the selected range of the active textbox, clamped to buf
+func (ctx *Context) textSelection(buf string) (int, int) {
+	lo := mu_clamp(mu_min(ctx.TextCursor, ctx.TextAnchor), 0, len(buf))
+	hi := mu_clamp(mu_max(ctx.TextCursor, ctx.TextAnchor), 0, len(buf))
+	return lo, hi
+}
+
+// replaces the selection of the active textbox with str and places the caret
+// after it
+func (ctx *Context) textReplaceSelection(buf *string, str string) {
+	lo, hi := ctx.textSelection(*buf)
+	*buf = (*buf)[:lo] + str + (*buf)[hi:]
+	ctx.TextCursor = lo + len(str)
+	ctx.TextAnchor = ctx.TextCursor
+}
+
+// moves the caret to pos, extending the selection if shift is held
+func (ctx *Context) textMoveCursor(pos int) {
+	ctx.TextCursor = pos
+	if (ctx.KeyDown & MU_KEY_SHIFT) == 0 {
+		ctx.TextAnchor = pos
+	}
+}
+
+// a line of a multiline text edit as a range of byte offsets, not including
+// the newline or the space the line was wrapped at
+type textLine struct {
+	Start, End int
+}
+
+// returns the index of the line that contains byte offset pos
+func textLineOf(lines []textLine, pos int) int {
+	i := 0
+	for i+1 < len(lines) && lines[i+1].Start <= pos {
+		i++
+	}
+	return i
+}
+
+// splits text into lines at newlines and soft wraps them so that no line is
+// wider than width. lines are wrapped at spaces if possible, otherwise at
+// grapheme cluster boundaries. there is always at least one line
+func (ctx *Context) textWrapLines(font Font, text string, width int) []textLine {
+	var lines []textLine
+	start := 0
+	for {
+		parEnd := strings.IndexByte(text[start:], '\n')
+		if parEnd < 0 {
+			parEnd = len(text)
+		} else {
+			parEnd += start
+		}
+		pos := start
+		for {
+			if ctx.TextWidth(font, text[pos:parEnd]) <= width {
+				lines = append(lines, textLine{pos, parEnd})
+				break
+			}
+			// find the last space the line can be wrapped at
+			end := pos
+			for i := pos; i < parEnd; {
+				j := strings.IndexByte(text[i:parEnd], ' ')
+				if j < 0 {
+					break
+				}
+				j += i
+				if ctx.TextWidth(font, text[pos:j]) > width {
+					break
+				}
+				end = j
+				i = j + 1
+			}
+			if end == pos {
+				end = ctx.textFitIndex(font, text, pos, parEnd, width)
+				lines = append(lines, textLine{pos, end})
+				// stop at the end of the paragraph, an empty line doesn't fit
+				// either if width is negative
+				if end == parEnd {
+					break
+				}
+				pos = end
+			} else {
+				lines = append(lines, textLine{pos, end})
+				pos = end + 1
+			}
+		}
+		if parEnd == len(text) {
+			return lines
+		}
+		start = parEnd + 1
+	}
+}
+
+// handles keyboard and mouse editing of buf for the focused text control with
+// the given id. indexAt maps a mouse position to a byte offset in buf. lines
+// is nil for single line controls, otherwise it returns the current lines of
+// buf and enables newlines and up/down navigation
+func (ctx *Context) textEdit(buf *string, id mu_Id, r Rect, indexAt func(pos Vec2) int, lines func() []textLine) int {
+	var res int = 0
+	font := ctx.Style.Font
+	multiline := lines != nil
+
+	if ctx.TextEdit != id {
+		// the control just got focused, place the caret at the end
+		ctx.TextEdit = id
+		ctx.TextCursor = len(*buf)
+		ctx.TextAnchor = ctx.TextCursor
+		if ctx.MousePressed == MU_MOUSE_LEFT && ctx.MouseOver(r) {
+			ctx.TextCursor = indexAt(ctx.MousePos)
+			ctx.TextAnchor = ctx.TextCursor
+		}
+	} else if ctx.MouseDown == MU_MOUSE_LEFT {
+		// place the caret on click, select by dragging
+		pos := indexAt(ctx.MousePos)
+		if ctx.MousePressed == MU_MOUSE_LEFT {
+			if ctx.MouseOver(r) {
+				ctx.textMoveCursor(pos)
+			}
+		} else {
+			ctx.TextCursor = pos
+		}
+	}
+	ctx.TextCursor = snapCharPos(*buf, mu_max(ctx.TextCursor, 0))
+	ctx.TextAnchor = snapCharPos(*buf, mu_max(ctx.TextAnchor, 0))
+	ctrl := (ctx.KeyDown & MU_KEY_CTRL) != 0
+
+	// handle undo and redo
+	if dir := ctx.undoKey(); dir != 0 && ctx.textUndoRedo(buf, id, dir) {
+		res |= MU_RES_CHANGE
+	}
+	before := textState{*buf, ctx.TextCursor, ctx.TextAnchor}
+	lo, hi := ctx.textSelection(*buf)
+
+	// handle text input
+	if len(ctx.TextInput) > 0 {
+		ctx.textReplaceSelection(buf, string(ctx.TextInput))
+		res |= MU_RES_CHANGE
+	}
+	// handle clipboard and select all
+	if ctrl {
+		if (ctx.KeyPressed & MU_KEY_A) != 0 {
+			ctx.TextAnchor = 0
+			ctx.TextCursor = len(*buf)
+		}
+		if (ctx.KeyPressed&(MU_KEY_C|MU_KEY_X)) != 0 && lo != hi && ctx.SetClipboard != nil {
+			ctx.SetClipboard((*buf)[lo:hi])
+			if (ctx.KeyPressed & MU_KEY_X) != 0 {
+				ctx.textReplaceSelection(buf, "")
+				res |= MU_RES_CHANGE
+			}
+		}
+		if (ctx.KeyPressed&MU_KEY_V) != 0 && ctx.GetClipboard != nil {
+			text := ctx.GetClipboard()
+			if !multiline {
+				text = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(text)
+			}
+			if text != "" {
+				ctx.textReplaceSelection(buf, text)
+				res |= MU_RES_CHANGE
+			}
+		}
+		lo, hi = ctx.textSelection(*buf)
+	}
+	// handle backspace and delete
+	if (ctx.KeyPressed & (MU_KEY_BACKSPACE | MU_KEY_DELETE)) != 0 {
+		if lo == hi {
+			if (ctx.KeyPressed & MU_KEY_BACKSPACE) != 0 {
+				if ctrl {
+					ctx.TextAnchor = prevWordPos(*buf, ctx.TextCursor)
+				} else {
+					ctx.TextAnchor = prevCharPos(*buf, ctx.TextCursor)
+				}
+			} else {
+				if ctrl {
+					ctx.TextAnchor = nextWordPos(*buf, ctx.TextCursor)
+				} else {
+					ctx.TextAnchor = nextCharPos(*buf, ctx.TextCursor)
+				}
+			}
+		}
+		if ctx.TextAnchor != ctx.TextCursor {
+			ctx.textReplaceSelection(buf, "")
+			res |= MU_RES_CHANGE
+		}
+	}
+	// handle return
+	if (ctx.KeyPressed & MU_KEY_RETURN) != 0 {
+		if multiline {
+			ctx.textReplaceSelection(buf, "\n")
+			res |= MU_RES_CHANGE
+		} else {
+			ctx.SetFocus(0)
+			res |= MU_RES_SUBMIT
+		}
+	}
+	// handle caret movement
+	if (ctx.KeyPressed & MU_KEY_LEFT) != 0 {
+		if lo != hi && (ctx.KeyDown&MU_KEY_SHIFT) == 0 {
+			ctx.textMoveCursor(lo)
+		} else if ctrl {
+			ctx.textMoveCursor(prevWordPos(*buf, ctx.TextCursor))
+		} else {
+			ctx.textMoveCursor(prevCharPos(*buf, ctx.TextCursor))
+		}
+	}
+	if (ctx.KeyPressed & MU_KEY_RIGHT) != 0 {
+		if lo != hi && (ctx.KeyDown&MU_KEY_SHIFT) == 0 {
+			ctx.textMoveCursor(hi)
+		} else if ctrl {
+			ctx.textMoveCursor(nextWordPos(*buf, ctx.TextCursor))
+		} else {
+			ctx.textMoveCursor(nextCharPos(*buf, ctx.TextCursor))
+		}
+	}
+	if !multiline || ctrl {
+		if (ctx.KeyPressed & MU_KEY_HOME) != 0 {
+			ctx.textMoveCursor(0)
+		}
+		if (ctx.KeyPressed & MU_KEY_END) != 0 {
+			ctx.textMoveCursor(len(*buf))
+		}
+	} else if (ctx.KeyPressed & (MU_KEY_HOME | MU_KEY_END | MU_KEY_UP | MU_KEY_DOWN)) != 0 {
+		l := lines()
+		li := textLineOf(l, ctx.TextCursor)
+		if (ctx.KeyPressed & MU_KEY_HOME) != 0 {
+			ctx.textMoveCursor(l[li].Start)
+		}
+		if (ctx.KeyPressed & MU_KEY_END) != 0 {
+			ctx.textMoveCursor(l[li].End)
+		}
+		// keep the caret at the same x position when changing lines
+		lj := li
+		if (ctx.KeyPressed & MU_KEY_UP) != 0 {
+			lj--
+		}
+		if (ctx.KeyPressed & MU_KEY_DOWN) != 0 {
+			lj++
+		}
+		if lj < 0 {
+			ctx.textMoveCursor(0)
+		} else if lj >= len(l) {
+			ctx.textMoveCursor(len(*buf))
+		} else if lj != li {
+			x := ctx.TextWidth(font, (*buf)[l[li].Start:ctx.TextCursor])
+			line := (*buf)[l[lj].Start:l[lj].End]
+			ctx.textMoveCursor(l[lj].Start + ctx.textIndexAt(font, line, x))
+		}
+	}
+
+	// record undo history
+	if *buf != before.text {
+		kind := textEditOther
+		if !ctrl && len(ctx.TextInput) > 0 &&
+			(ctx.KeyPressed&(MU_KEY_RETURN|MU_KEY_BACKSPACE|MU_KEY_DELETE)) == 0 {
+			kind = textEditTyping
+		} else if !ctrl && len(ctx.TextInput) == 0 &&
+			(ctx.KeyPressed&(MU_KEY_BACKSPACE|MU_KEY_DELETE)) != 0 {
+			kind = textEditDeleting
+		}
+		ctx.textPushUndo(id, before, kind)
+	} else if ctx.TextCursor != before.cursor || ctx.TextAnchor != before.anchor {
+		ctx.textBreakUndo(id)
+	}
+
+	return res
+}
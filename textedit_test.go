@@ -0,0 +1,139 @@
+package microui
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+// returns a context that measures every rune as 8 pixels wide and 10 high
+func newTestContext() *Context {
+	ctx := NewContext()
+	ctx.TextWidth = func(font Font, str string) int {
+		return utf8.RuneCountInString(str) * 8
+	}
+	ctx.TextHeight = func(font Font) int {
+		return 10
+	}
+	return ctx
+}
+
+func TestTextWrapLines(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  []textLine
+	}{
+		{"", 100, []textLine{{0, 0}}},
+		{"hello", 100, []textLine{{0, 5}}},
+		{"hello world", 48, []textLine{{0, 5}, {6, 11}}},
+		{"a\nb", 100, []textLine{{0, 1}, {2, 3}}},
+		{"a\n", 100, []textLine{{0, 1}, {2, 2}}},
+		// no space to wrap at, break between characters
+		{"abcdef", 16, []textLine{{0, 2}, {2, 4}, {4, 6}}},
+		// narrower than a single character, one character per line
+		{"abc", 0, []textLine{{0, 1}, {1, 2}, {2, 3}}},
+		{"abc", -12, []textLine{{0, 1}, {1, 2}, {2, 3}}},
+		{"", -12, []textLine{{0, 0}}},
+		{"a\n\nb", -12, []textLine{{0, 1}, {2, 2}, {3, 4}}},
+	}
+	ctx := newTestContext()
+	for _, tt := range tests {
+		got := ctx.textWrapLines(nil, tt.text, tt.width)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("textWrapLines(%q, %d) = %v, want %v", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestTextAreaNarrow(t *testing.T) {
+	ctx := newTestContext()
+	buf := "some text"
+	var content Rect
+	ui := func() {
+		// narrower than the padding on both sides of the text
+		ctx.LayoutRow(1, []int{6}, 60)
+		ctx.TextArea(&buf)
+		// the wrapped lines are laid out as a single item
+		content = ctx.LastRect
+	}
+	testFrame(ctx, ui)
+	testFrame(ctx, ui)
+
+	if content.W >= 0 {
+		t.Fatalf("text area body is %d wide, want a negative width", content.W)
+	}
+	// every character gets a line of its own
+	lines := ctx.textWrapLines(nil, buf, content.W)
+	want := []textLine{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 7}, {7, 8}, {8, 9}}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %v, want %v", lines, want)
+	}
+	if content.H != len(want)*10 {
+		t.Errorf("content height = %d, want %d", content.H, len(want)*10)
+	}
+}
+
+func TestCharPos(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		// byte offsets of the grapheme cluster boundaries, excluding 0
+		bounds []int
+	}{
+		{"ascii", "ab", []int{1, 2}},
+		{"two byte runes", "éü", []int{2, 4}},
+		{"combining marks", "ẹ́x", []int{5, 6}},
+		{"zwj emoji", "👩‍💻a", []int{11, 12}},
+		{"family", "👨‍👩‍👧", []int{18}},
+		{"skin tone", "👍🏽!", []int{8, 9}},
+		{"variation selector", "❤️a", []int{6, 7}},
+		{"flags", "🇩🇪🇫🇷", []int{8, 16}},
+		{"odd regional indicators", "🇩🇪🇫", []int{8, 12}},
+		{"crlf", "a\r\nb", []int{1, 3, 4}},
+	}
+	for _, tt := range tests {
+		var got []int
+		for i := 0; i < len(tt.s); {
+			i = nextCharPos(tt.s, i)
+			got = append(got, i)
+		}
+		if !reflect.DeepEqual(got, tt.bounds) {
+			t.Errorf("%s: nextCharPos boundaries = %v, want %v", tt.name, got, tt.bounds)
+		}
+		// walking backwards from the end has to visit the same boundaries
+		var back []int
+		for i := len(tt.s); i > 0; {
+			back = append([]int{i}, back...)
+			i = prevCharPos(tt.s, i)
+		}
+		if !reflect.DeepEqual(back, tt.bounds) {
+			t.Errorf("%s: prevCharPos boundaries = %v, want %v", tt.name, back, tt.bounds)
+		}
+	}
+	// positions inside a cluster go to the start of that cluster
+	if got := prevCharPos("éx", 2); got != 0 {
+		t.Errorf("prevCharPos inside a cluster = %d, want 0", got)
+	}
+	if got := nextCharPos("ab", 5); got != 2 {
+		t.Errorf("nextCharPos past the end = %d, want 2", got)
+	}
+}
+
+// backspace has to delete a whole cluster, not just its last byte or rune
+func TestTextBoxBackspaceCluster(t *testing.T) {
+	ctx := newTestContext()
+	buf := "a👩‍💻"
+	var r Rect
+	ui := func() {
+		ctx.LayoutRow(1, []int{-1}, 0)
+		ctx.TextBox(&buf)
+		r = ctx.LastRect
+	}
+	testFrame(ctx, ui)
+	testClick(ctx, ui, Vec2{r.X + r.W - 5, r.Y + r.H/2})
+	testKey(ctx, ui, MU_KEY_BACKSPACE)
+	if buf != "a" {
+		t.Errorf("backspace gave %q, want %q", buf, "a")
+	}
+}
@@ -0,0 +1,48 @@
+package microui
+
+/*============================================================================
+** tooltips
+**============================================================================*/
+
+// counts how long the hovered control has been hovered for. pressing a mouse
+// button restarts the count. called from End()
+func (ctx *Context) updateHoverTime() {
+	if ctx.Hover == 0 || ctx.Hover != ctx.hoverID || ctx.MouseDown != 0 {
+		ctx.hoverID = ctx.Hover
+		ctx.hoverTime = 0
+		return
+	}
+	ctx.hoverTime += ctx.DeltaTime
+}
+
+// begins a tooltip for the last control if it has been hovered for at least
+// ctx.TooltipDelay seconds. returns true if the tooltip is shown, in which
+// case its contents should be added and EndTooltip called
+func (ctx *Context) BeginTooltip() bool {
+	if ctx.LastID == 0 || ctx.Hover != ctx.LastID || ctx.hoverID != ctx.LastID ||
+		ctx.hoverTime < ctx.TooltipDelay {
+		return false
+	}
+	// offset from the mouse so the tooltip never becomes the hover root
+	offset := ctx.Style.Size.Y + ctx.Style.Padding
+	cnt := ctx.GetContainer("!tooltip")
+	cnt.Rect.X = ctx.MousePos.X + offset
+	cnt.Rect.Y = ctx.MousePos.Y + offset
+	ctx.BringToFront(cnt)
+	opt := MU_OPT_AUTOSIZE | MU_OPT_NORESIZE | MU_OPT_NOSCROLL | MU_OPT_NOTITLE
+	return ctx.BeginWindowEx("!tooltip", cnt.Rect, opt) != 0
+}
+
+func (ctx *Context) EndTooltip() {
+	ctx.EndWindow()
+}
+
+// shows text in a tooltip for the last control, see BeginTooltip
+func (ctx *Context) Tooltip(text string) {
+	if !ctx.BeginTooltip() {
+		return
+	}
+	ctx.LayoutRow(1, []int{ctx.TextWidth(ctx.Style.Font, text) + ctx.Style.Padding*2}, 0)
+	ctx.Label(text)
+	ctx.EndTooltip()
+}
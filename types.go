@@ -62,6 +62,12 @@ type IconCommand struct {
 	Color Color
 }
 
+type navItem struct {
+	ID   mu_Id
+	Rect Rect
+	Root *Container
+}
+
 type Layout struct {
 	Body      Rect
 	Next      Rect
@@ -102,6 +108,48 @@ func (c *Container) Clear() {
 	*c = Container{}
 }
 
+type TabBarItem struct {
+	ID    mu_Id
+	Width int
+	Frame int // last frame the tab was submitted on
+}
+
+type TabBar struct {
+	Rect     Rect
+	Selected mu_Id
+	Items    []TabBarItem // tabs in display order
+	Opt      int
+}
+
+type TableColumn struct {
+	Label  string
+	Weight float32 // initial share of the table width
+	Opt    int
+}
+
+type Table struct {
+	Columns        []TableColumn
+	Widths         []float32 // column widths as fractions of the table width
+	SortColumn     int       // -1 if the table isn't sorted
+	SortDescending bool
+	Opt            int
+
+	sortDirty bool
+	count     int  // number of columns passed to BeginTable
+	header    bool // header row was drawn this frame
+	row       Rect
+	column    int // index of the current cell in the row
+}
+
+// the range of items of a list that are visible, see BeginListClipper
+type ListClipper struct {
+	Start, End int
+
+	count int
+	step  int // height of an item including spacing
+	top   int // layout row the list starts at
+}
+
 type Style struct {
 	Font          Font
 	Size          Vec2
@@ -121,6 +169,10 @@ type Context struct {
 	TextHeight func(font Font) int
 	DrawFrame  func(ctx *Context, rect Rect, colorid int)
 
+	// optional, used by text controls for copy and paste
+	GetClipboard func() string
+	SetClipboard func(text string)
+
 	// core state
 
 	_style        Style
@@ -137,6 +189,41 @@ type Context struct {
 	ScrollTarget  *Container
 	NumberEditBuf string
 	NumberEdit    mu_Id
+	TextEdit      mu_Id // id of the textbox that owns the caret
+	TextCursor    int   // caret position as a byte offset
+	TextAnchor    int   // other end of the selection, equals TextCursor if empty
+	KeyboardFocus bool  // focus was set by keyboard navigation
+	// enables moving focus with the arrow keys (e.g. mapped from a gamepad's
+	// d-pad) to the nearest control in that direction
+	DirectionalNav bool
+
+	comboHighlight         int  // item highlighted in the open combo popup
+	comboScrollToHighlight bool // scroll the highlighted item into view
+
+	menuPath      []mu_Id // popup ids of the open menus, outermost first
+	menuBar       mu_Id   // id of the menu bar the open menus belong to
+	menuOpenFrame int     // frame the last menu was opened on
+
+	contextMenu      mu_Id      // id of the last opened context menu
+	contextMenuFrame int        // frame it was opened on
+	contextMenuRoot  *Container // hover root before it was opened
+
+	colorPickerID  mu_Id      // id of the color picker colorPickerHSV belongs to
+	colorPickerHSV [3]float32 // hue, saturation and value of that picker
+
+	// state for the next tree node set by SetNextTreeNodeOpen, 0 if unset,
+	// 1 for open and 2 for closed
+	nextTreeNodeOpen int
+	// the last header or tree node was clicked, see TreeNodeClicked
+	treeNodeClicked bool
+
+	listAnchorID mu_Id // list box listAnchor belongs to
+	listAnchor   int   // item shift+click selects a range from
+
+	// seconds a control has to be hovered before its tooltip is shown
+	TooltipDelay float32
+	hoverID      mu_Id   // control hoverTime is being counted for
+	hoverTime    float32 // seconds hoverID has been hovered for
 
 	// stacks
 
@@ -146,12 +233,34 @@ type Context struct {
 	ClipStack      []Rect
 	IdStack        []mu_Id
 	LayoutStack    []Layout
+	TabBarStack    []*TabBar
+	TableStack     []*Table
+	menuStack      []menuLevel
 
 	// retained state pools
 
 	ContainerPool [MU_CONTAINERPOOL_SIZE]MuPoolItem
 	Containers    [MU_CONTAINERPOOL_SIZE]Container
 	TreeNodePool  [MU_TREENODEPOOL_SIZE]MuPoolItem
+	TextUndoPool  [MU_TEXTUNDOPOOL_SIZE]MuPoolItem
+	TabBarPool    [MU_TABBARPOOL_SIZE]MuPoolItem
+	TabBars       [MU_TABBARPOOL_SIZE]TabBar
+	TablePool     [MU_TABLEPOOL_SIZE]MuPoolItem
+	Tables        [MU_TABLEPOOL_SIZE]Table
+	textUndo      [MU_TEXTUNDOPOOL_SIZE]textHistory
+
+	// undo history
+
+	undoStack  []undoEntry
+	redoStack  []undoEntry
+	undoID     mu_Id       // id of the value control an undo entry is being recorded for
+	undoCommit func()      // pushes the entry for undoID once its interaction ends
+	undoLate   func()      // undoCommit of the previous control, run in End()
+	undoQueue  []undoEntry // entries pushed while a commit was pending
+
+	// controls that can be focused by keyboard navigation, in the order they
+	// were updated this frame
+	navList []navItem
 
 	// input state
 
@@ -164,4 +273,11 @@ type Context struct {
 	KeyDown      int
 	KeyPressed   int
 	TextInput    []rune
+	DeltaTime    float32 // seconds passed since the last frame, see InputTime
+	Time         float64 // seconds passed since the first frame
+
+	// input recording
+
+	recording   *InputRecording
+	recordStart int
 }
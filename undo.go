@@ -0,0 +1,185 @@
+package microui
+
+/*============================================================================
+** undo
+**============================================================================*/
+
+type undoEntry struct {
+	undo, redo func()
+}
+
+// pushes an entry to the context's undo stack. undo is called by Undo() to
+// revert the change and redo is called by Redo() to apply it again. pushing
+// an entry clears the redo stack
+func (ctx *Context) PushUndo(undo, redo func()) {
+	e := undoEntry{undo, redo}
+	if ctx.undoCommit != nil || ctx.undoLate != nil {
+		// the entry of a value control that loses focus this frame is only
+		// pushed when the control stores its value, which can be later in
+		// the frame. queue this one until End() so that it comes after it
+		ctx.undoQueue = append(ctx.undoQueue, e)
+		return
+	}
+	ctx.pushUndo(e)
+}
+
+func (ctx *Context) pushUndo(e undoEntry) {
+	if len(ctx.undoStack) >= MU_UNDOSTACK_SIZE {
+		ctx.undoStack = append(ctx.undoStack[:0], ctx.undoStack[1:]...)
+	}
+	ctx.undoStack = append(ctx.undoStack, e)
+	ctx.redoStack = ctx.redoStack[:0]
+}
+
+// reverts the last change on the undo stack, returns false if there is none
+func (ctx *Context) Undo() bool {
+	if len(ctx.undoStack) == 0 {
+		return false
+	}
+	e := ctx.undoStack[len(ctx.undoStack)-1]
+	ctx.undoStack = ctx.undoStack[:len(ctx.undoStack)-1]
+	e.undo()
+	ctx.redoStack = append(ctx.redoStack, e)
+	return true
+}
+
+// applies the last undone change again, returns false if there is none
+func (ctx *Context) Redo() bool {
+	if len(ctx.redoStack) == 0 {
+		return false
+	}
+	e := ctx.redoStack[len(ctx.redoStack)-1]
+	ctx.redoStack = ctx.redoStack[:len(ctx.redoStack)-1]
+	e.redo()
+	ctx.undoStack = append(ctx.undoStack, e)
+	return true
+}
+
+func (ctx *Context) ClearUndo() {
+	ctx.undoStack = ctx.undoStack[:0]
+	ctx.redoStack = ctx.redoStack[:0]
+	ctx.undoID = 0
+	ctx.undoCommit = nil
+	ctx.undoLate = nil
+	ctx.undoQueue = ctx.undoQueue[:0]
+}
+
+// returns 1 for ctrl+z, 2 for ctrl+y or ctrl+shift+z and 0 otherwise
+func (ctx *Context) undoKey() int {
+	if (ctx.KeyDown & MU_KEY_CTRL) == 0 {
+		return 0
+	}
+	if (ctx.KeyPressed&MU_KEY_Y) != 0 ||
+		((ctx.KeyPressed&MU_KEY_Z) != 0 && (ctx.KeyDown&MU_KEY_SHIFT) != 0) {
+		return 2
+	}
+	if (ctx.KeyPressed & MU_KEY_Z) != 0 {
+		return 1
+	}
+	return 0
+}
+
+// records a single undo entry for an interaction with a value control, which
+// lasts from the control getting focused until it loses focus. last is the
+// value before this frame's input was applied
+func undoTrackValue[T comparable](ctx *Context, id mu_Id, value *T, last T) {
+	if ctx.Focus == id && ctx.undoID != id {
+		if ctx.undoCommit != nil {
+			// focus moved here straight from another value control. it may
+			// come later in the layout and only store its value then, so its
+			// entry is pushed at the end of the frame
+			ctx.undoLate = ctx.undoCommit
+		}
+		ctx.undoID = id
+		ctx.undoCommit = func() {
+			if v := *value; v != last {
+				ctx.pushUndo(undoEntry{func() { *value = last }, func() { *value = v }})
+			}
+		}
+	} else if ctx.Focus != id && ctx.undoID == id {
+		ctx.undoCommit()
+		ctx.undoID = 0
+		ctx.undoCommit = nil
+	}
+}
+
+/*============================================================================
+** text undo
+**============================================================================*/
+
+const (
+	textEditOther = iota
+	textEditTyping
+	textEditDeleting
+)
+
+type textState struct {
+	text           string
+	cursor, anchor int
+}
+
+// per-control text edit history, kept in ctx.TextUndoPool
+type textHistory struct {
+	undo, redo []textState
+	// kind of the last edit, consecutive typing or deleting is merged into a
+	// single undo step
+	lastKind int
+}
+
+func (ctx *Context) textHistory(id mu_Id, create bool) *textHistory {
+	idx := ctx.PoolGet(ctx.TextUndoPool[:], id)
+	if idx >= 0 {
+		ctx.PoolUpdate(ctx.TextUndoPool[:], idx)
+		return &ctx.textUndo[idx]
+	}
+	if !create {
+		return nil
+	}
+	idx = ctx.PoolInit(ctx.TextUndoPool[:], id)
+	ctx.textUndo[idx] = textHistory{}
+	return &ctx.textUndo[idx]
+}
+
+// records the state of a text control before an edit of the given kind
+func (ctx *Context) textPushUndo(id mu_Id, before textState, kind int) {
+	h := ctx.textHistory(id, true)
+	if kind == textEditOther || kind != h.lastKind || len(h.undo) == 0 {
+		if len(h.undo) >= MU_UNDOSTACK_SIZE {
+			h.undo = append(h.undo[:0], h.undo[1:]...)
+		}
+		h.undo = append(h.undo, before)
+	}
+	h.redo = h.redo[:0]
+	h.lastKind = kind
+}
+
+// undoes (dir = 1) or redoes (dir = 2) the last edit of the text control,
+// returns false if there was nothing to do
+func (ctx *Context) textUndoRedo(buf *string, id mu_Id, dir int) bool {
+	h := ctx.textHistory(id, false)
+	if h == nil {
+		return false
+	}
+	from, to := &h.undo, &h.redo
+	if dir == 2 {
+		from, to = to, from
+	}
+	if len(*from) == 0 {
+		return false
+	}
+	st := (*from)[len(*from)-1]
+	*from = (*from)[:len(*from)-1]
+	*to = append(*to, textState{*buf, ctx.TextCursor, ctx.TextAnchor})
+	*buf = st.text
+	ctx.TextCursor = st.cursor
+	ctx.TextAnchor = st.anchor
+	h.lastKind = textEditOther
+	return true
+}
+
+// ends merging of consecutive edits, called when the caret moves
+func (ctx *Context) textBreakUndo(id mu_Id) {
+	if h := ctx.textHistory(id, false); h != nil {
+		h.lastKind = textEditOther
+	}
+}
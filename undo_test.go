@@ -0,0 +1,143 @@
+package microui
+
+import "testing"
+
+// shift+clicks number b to edit it as text, types into it and then clicks
+// number a, which takes the focus straight from b. the edit of b must be
+// undoable no matter which of the two comes first in the layout
+func TestUndoNumberTextFocusChange(t *testing.T) {
+	for _, aFirst := range []bool{true, false} {
+		ctx := newTestContext()
+		a, b := float32(1), float32(2)
+		var ra, rb Rect
+		ui := func() {
+			ctx.LayoutRow(1, []int{-1}, 0)
+			if aFirst {
+				ctx.Number(&a, 1)
+				ra = ctx.LastRect
+			}
+			ctx.Number(&b, 1)
+			rb = ctx.LastRect
+			if !aFirst {
+				ctx.Number(&a, 1)
+				ra = ctx.LastRect
+			}
+		}
+		testFrame(ctx, ui)
+
+		ctx.InputKeyDown(MU_KEY_SHIFT)
+		testClick(ctx, ui, Vec2{rb.X + 5, rb.Y + 5})
+		ctx.InputKeyUp(MU_KEY_SHIFT)
+		ctx.InputText([]rune("5"))
+		testFrame(ctx, ui)
+		testClick(ctx, ui, Vec2{ra.X + 5, ra.Y + 5})
+		if b == 2 {
+			t.Fatalf("aFirst = %v: typing didn't change b", aFirst)
+		}
+
+		ctx.InputKeyDown(MU_KEY_CTRL)
+		testKey(ctx, ui, MU_KEY_Z)
+		ctx.InputKeyUp(MU_KEY_CTRL)
+		if a != 1 || b != 2 {
+			t.Errorf("aFirst = %v: after undo a = %v, b = %v, want 1, 2", aFirst, a, b)
+		}
+	}
+}
+
+// drags a slider and then toggles a checkbox, undo has to revert them in the
+// reverse order no matter how they are laid out
+func TestUndoDragThenCheckbox(t *testing.T) {
+	for _, checkFirst := range []bool{true, false} {
+		ctx := newTestContext()
+		v := float32(0)
+		checked := false
+		var rs, rc Rect
+		ui := func() {
+			ctx.LayoutRow(1, []int{-1}, 0)
+			if checkFirst {
+				ctx.Checkbox("check", &checked)
+				rc = ctx.LastRect
+			}
+			ctx.Slider(&v, 0, 100)
+			rs = ctx.LastRect
+			if !checkFirst {
+				ctx.Checkbox("check", &checked)
+				rc = ctx.LastRect
+			}
+		}
+		testFrame(ctx, ui)
+
+		// drag the slider to the right end
+		p := Vec2{rs.X + 5, rs.Y + rs.H/2}
+		ctx.InputMouseMove(p.X, p.Y)
+		testFrame(ctx, ui)
+		testFrame(ctx, ui)
+		ctx.InputMouseDown(p.X, p.Y, MU_MOUSE_LEFT)
+		testFrame(ctx, ui)
+		ctx.InputMouseMove(rs.X+rs.W, p.Y)
+		testFrame(ctx, ui)
+		ctx.InputMouseUp(rs.X+rs.W, p.Y, MU_MOUSE_LEFT)
+		testFrame(ctx, ui)
+		testClick(ctx, ui, Vec2{rc.X + 5, rc.Y + 5})
+		if v != 100 || !checked {
+			t.Fatalf("checkFirst = %v: v = %v, checked = %v after the input", checkFirst, v, checked)
+		}
+
+		ctx.InputKeyDown(MU_KEY_CTRL)
+		testKey(ctx, ui, MU_KEY_Z)
+		if v != 100 || checked {
+			t.Errorf("checkFirst = %v: first undo gave v = %v, checked = %v, want 100, false", checkFirst, v, checked)
+		}
+		testKey(ctx, ui, MU_KEY_Z)
+		ctx.InputKeyUp(MU_KEY_CTRL)
+		if v != 0 || checked {
+			t.Errorf("checkFirst = %v: second undo gave v = %v, checked = %v, want 0, false", checkFirst, v, checked)
+		}
+	}
+}
+
+// edits a number as text and clicks a checkbox while the number still holds
+// the focus, so the number's entry is only pushed after the checkbox's
+func TestUndoNumberEditThenCheckbox(t *testing.T) {
+	for _, checkFirst := range []bool{true, false} {
+		ctx := newTestContext()
+		v := float32(1)
+		checked := false
+		var rn, rc Rect
+		ui := func() {
+			ctx.LayoutRow(1, []int{-1}, 0)
+			if checkFirst {
+				ctx.Checkbox("check", &checked)
+				rc = ctx.LastRect
+			}
+			ctx.Number(&v, 1)
+			rn = ctx.LastRect
+			if !checkFirst {
+				ctx.Checkbox("check", &checked)
+				rc = ctx.LastRect
+			}
+		}
+		testFrame(ctx, ui)
+
+		ctx.InputKeyDown(MU_KEY_SHIFT)
+		testClick(ctx, ui, Vec2{rn.X + 5, rn.Y + 5})
+		ctx.InputKeyUp(MU_KEY_SHIFT)
+		ctx.InputText([]rune("5"))
+		testFrame(ctx, ui)
+		testClick(ctx, ui, Vec2{rc.X + 5, rc.Y + 5})
+		if v == 1 || !checked {
+			t.Fatalf("checkFirst = %v: v = %v, checked = %v after the input", checkFirst, v, checked)
+		}
+
+		ctx.InputKeyDown(MU_KEY_CTRL)
+		testKey(ctx, ui, MU_KEY_Z)
+		if v == 1 || checked {
+			t.Errorf("checkFirst = %v: first undo gave v = %v, checked = %v, want the checkbox reverted", checkFirst, v, checked)
+		}
+		testKey(ctx, ui, MU_KEY_Z)
+		ctx.InputKeyUp(MU_KEY_CTRL)
+		if v != 1 || checked {
+			t.Errorf("checkFirst = %v: second undo gave v = %v, checked = %v, want 1, false", checkFirst, v, checked)
+		}
+	}
+}
@@ -8,10 +8,26 @@ func (ctx *Context) TextBox(buf *string) int {
 	return ctx.TextBoxEx(buf, 0)
 }
 
+func (ctx *Context) TextArea(buf *string) int {
+	return ctx.TextAreaEx(buf, 0)
+}
+
+func (ctx *Context) Combo(label string, selected *int, items []string) int {
+	return ctx.ComboEx(label, selected, items, 0)
+}
+
+func (ctx *Context) Radio(label string, active bool) bool {
+	return ctx.RadioEx(label, active, 0)
+}
+
 func (ctx *Context) Slider(value *float32, lo, hi float32) int {
 	return ctx.SliderEx(value, lo, hi, 0, MU_SLIDER_FMT, MU_OPT_ALIGNCENTER)
 }
 
+func (ctx *Context) RangeSlider(lo, hi *float32, low, high float32) int {
+	return ctx.RangeSliderEx(lo, hi, low, high, 0, MU_SLIDER_FMT, MU_OPT_ALIGNCENTER)
+}
+
 func (ctx *Context) Number(value *float32, step float32) int {
 	return ctx.NumberEx(value, step, MU_SLIDER_FMT, MU_OPT_ALIGNCENTER)
 }
@@ -24,6 +40,18 @@ func (ctx *Context) BeginTreeNode(label string) bool {
 	return ctx.BeginTreeNodeEx(label, 0) != 0
 }
 
+func (ctx *Context) BeginTabBar(name string) {
+	ctx.BeginTabBarEx(name, 0)
+}
+
+func (ctx *Context) TabItem(label string) bool {
+	return ctx.TabItemEx(label, nil, 0) != 0
+}
+
+func (ctx *Context) MenuItem(label, shortcut string, selected *bool) bool {
+	return ctx.MenuItemEx(label, shortcut, selected, 0)
+}
+
 func (ctx *Context) BeginWindow(title string, rect Rect) bool {
 	return ctx.BeginWindowEx(title, rect, 0) != 0
 }